@@ -7,21 +7,17 @@ import (
 )
 
 func (m *Markdown) callout(label, text string) *Markdown {
-	blockquote := ast.NewBlockquote()
-	head := ast.NewParagraph()
-	head.AppendChild(head, ast.NewString([]byte(label+"  ")))
-	blockquote.AppendChild(blockquote, head)
-
+	callout := newCalloutBlock(label)
 	body := ast.NewParagraph()
 	body.AppendChild(body, ast.NewString([]byte(text)))
-	blockquote.AppendChild(blockquote, body)
+	callout.AppendChild(callout, body)
 
-	m.appendBlock(blockquote)
+	m.appendBlock(callout)
 	return m
 }
 
 // Note set text with note format.
-func (m *Markdown) Note(text string) *Markdown { return m.callout("[!NOTE]", text) }
+func (m *Markdown) Note(text string) *Markdown { return m.callout("NOTE", text) }
 
 // Notef set text with note format.
 func (m *Markdown) Notef(format string, args ...interface{}) *Markdown {
@@ -29,7 +25,7 @@ func (m *Markdown) Notef(format string, args ...interface{}) *Markdown {
 }
 
 // Tip set text with tip format.
-func (m *Markdown) Tip(text string) *Markdown { return m.callout("[!TIP]", text) }
+func (m *Markdown) Tip(text string) *Markdown { return m.callout("TIP", text) }
 
 // Tipf set text with tip format.
 func (m *Markdown) Tipf(format string, args ...interface{}) *Markdown {
@@ -37,7 +33,7 @@ func (m *Markdown) Tipf(format string, args ...interface{}) *Markdown {
 }
 
 // Important set text with important format.
-func (m *Markdown) Important(text string) *Markdown { return m.callout("[!IMPORTANT]", text) }
+func (m *Markdown) Important(text string) *Markdown { return m.callout("IMPORTANT", text) }
 
 // Importantf set text with important format.
 func (m *Markdown) Importantf(format string, args ...interface{}) *Markdown {
@@ -45,7 +41,7 @@ func (m *Markdown) Importantf(format string, args ...interface{}) *Markdown {
 }
 
 // Warning set text with warning format.
-func (m *Markdown) Warning(text string) *Markdown { return m.callout("[!WARNING]", text) }
+func (m *Markdown) Warning(text string) *Markdown { return m.callout("WARNING", text) }
 
 // Warningf set text with warning format.
 func (m *Markdown) Warningf(format string, args ...interface{}) *Markdown {
@@ -53,7 +49,7 @@ func (m *Markdown) Warningf(format string, args ...interface{}) *Markdown {
 }
 
 // Caution set text with caution format.
-func (m *Markdown) Caution(text string) *Markdown { return m.callout("[!CAUTION]", text) }
+func (m *Markdown) Caution(text string) *Markdown { return m.callout("CAUTION", text) }
 
 // Cautionf set text with caution format.
 func (m *Markdown) Cautionf(format string, args ...interface{}) *Markdown {
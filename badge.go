@@ -4,7 +4,7 @@ import "fmt"
 
 // RedBadge set text with red badge format.
 func (m *Markdown) RedBadge(text string) *Markdown {
-	return m.PlainText(fmt.Sprintf("![Badge](https://img.shields.io/badge/%s-red)", text))
+	return m.Raw(fmt.Sprintf("![Badge](https://img.shields.io/badge/%s-red)", text))
 }
 
 // RedBadgef set text with red badge format.
@@ -14,7 +14,7 @@ func (m *Markdown) RedBadgef(format string, args ...interface{}) *Markdown {
 
 // YellowBadge set text with yellow badge format.
 func (m *Markdown) YellowBadge(text string) *Markdown {
-	return m.PlainText(fmt.Sprintf("![Badge](https://img.shields.io/badge/%s-yellow)", text))
+	return m.Raw(fmt.Sprintf("![Badge](https://img.shields.io/badge/%s-yellow)", text))
 }
 
 // YellowBadgef set text with yellow badge format.
@@ -24,7 +24,7 @@ func (m *Markdown) YellowBadgef(format string, args ...interface{}) *Markdown {
 
 // GreenBadge set text with green badge format.
 func (m *Markdown) GreenBadge(text string) *Markdown {
-	return m.PlainText(fmt.Sprintf("![Badge](https://img.shields.io/badge/%s-green)", text))
+	return m.Raw(fmt.Sprintf("![Badge](https://img.shields.io/badge/%s-green)", text))
 }
 
 // GreenBadgef set text with green badge format.
@@ -34,7 +34,7 @@ func (m *Markdown) GreenBadgef(format string, args ...interface{}) *Markdown {
 
 // BlueBadge set text with blue badge format.
 func (m *Markdown) BlueBadge(text string) *Markdown {
-	return m.PlainText(fmt.Sprintf("![Badge](https://img.shields.io/badge/%s-blue)", text))
+	return m.Raw(fmt.Sprintf("![Badge](https://img.shields.io/badge/%s-blue)", text))
 }
 
 // BlueBadgef set text with blue badge format.
@@ -9,40 +9,43 @@ import (
 )
 
 // TableOfContents generates a table of contents from the recorded headers.
+// Only headings added before it are listed; use CustomTableOfContents for a
+// table of contents resolved at render time.
 func (m *Markdown) TableOfContents(depth TableOfContentsDepth) *Markdown {
 	if len(m.headers) == 0 {
 		return m
 	}
 
+	var lines []string
+	slugger := m.options.slugger()
 	for _, header := range m.headers {
+		// Every heading is slugged, in order, so duplicates are numbered as
+		// they are in the rendered document.
+		anchor := slugger.Slug(header.text)
 		if header.level > depth {
 			continue
 		}
 		indent := strings.Repeat("  ", int(header.level)-1)
-		anchor := buildAnchor(header.text)
-		line := fmt.Sprintf("%s- [%s](#%s)", indent, header.text, anchor)
-		m.appendBlock(newLiteralBlock(line))
+		label := header.text
+		if !m.options.rawText {
+			label = escapeText(label, escapeLinkText, false)
+		}
+		lines = append(lines, fmt.Sprintf("%s- [%s](#%s)", indent, label, anchor))
+	}
+	if len(lines) > 0 {
+		m.appendBlock(newLiteralBlock(strings.Join(lines, lineFeed())))
 	}
 	m.appendBlock(newLiteralBlock(""))
 	return m
 }
 
-func buildAnchor(text string) string {
-	anchor := strings.ToLower(text)
-	anchor = strings.ReplaceAll(anchor, " ", "-")
-	anchor = strings.Map(func(r rune) rune {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
-			return r
-		}
-		return -1
-	}, anchor)
-	return anchor
-}
-
-// Details renders an HTML <details> block.
+// Details renders a collapsible block: an HTML <details> block, or the
+// flavor's own syntax for Obsidian and MkDocs. summary and text are trusted
+// Markdown and written as is.
 func (m *Markdown) Details(summary, text string) *Markdown {
-	block := fmt.Sprintf("<details><summary>%s</summary>%s%s%s</details>", summary, lineFeed(), text, lineFeed())
-	m.appendBlock(newLiteralBlock(block))
+	details := newDetailsBlock(summary)
+	details.AppendChild(details, newLiteralBlock(text))
+	m.appendBlock(details)
 	return m
 }
 
@@ -56,17 +59,11 @@ func (m *Markdown) BulletList(items ...string) *Markdown {
 	if len(items) == 0 {
 		return m
 	}
-	list := ast.NewList('-')
-	list.IsTight = true
-	for _, item := range items {
-		listItem := ast.NewListItem(0)
-		paragraph := ast.NewParagraph()
-		paragraph.AppendChild(paragraph, ast.NewString([]byte(item)))
-		listItem.AppendChild(listItem, paragraph)
-		list.AppendChild(list, listItem)
-	}
-	m.appendBlock(list)
-	return m
+	return m.BulletListFunc(func(l *ListBuilder) {
+		for _, item := range items {
+			l.Item(item)
+		}
+	})
 }
 
 // OrderedList appends an ordered list.
@@ -74,18 +71,11 @@ func (m *Markdown) OrderedList(items ...string) *Markdown {
 	if len(items) == 0 {
 		return m
 	}
-	list := ast.NewList('.')
-	list.IsTight = true
-	list.Start = 1
-	for _, item := range items {
-		listItem := ast.NewListItem(0)
-		paragraph := ast.NewParagraph()
-		paragraph.AppendChild(paragraph, ast.NewString([]byte(item)))
-		listItem.AppendChild(listItem, paragraph)
-		list.AppendChild(list, listItem)
-	}
-	m.appendBlock(list)
-	return m
+	return m.OrderedListFunc(func(l *ListBuilder) {
+		for _, item := range items {
+			l.Item(item)
+		}
+	})
 }
 
 // CheckBox appends a checkbox list.
@@ -93,33 +83,27 @@ func (m *Markdown) CheckBox(set []CheckBoxSet) *Markdown {
 	if len(set) == 0 {
 		return m
 	}
-	list := ast.NewList('-')
-	list.IsTight = true
-	for _, entry := range set {
-		prefix := "[ ] "
-		if entry.Checked {
-			prefix = "[x] "
+	return m.BulletListFunc(func(l *ListBuilder) {
+		for _, entry := range set {
+			l.Task(entry.Checked, entry.Text)
 		}
-		paragraph := ast.NewParagraph()
-		paragraph.AppendChild(paragraph, ast.NewString([]byte(prefix+entry.Text)))
-		item := ast.NewListItem(0)
-		item.AppendChild(item, paragraph)
-		list.AppendChild(list, item)
-	}
-	m.appendBlock(list)
-	return m
+	})
 }
 
-// Blockquote appends a blockquote block.
+// Blockquote appends a blockquote block. Lines of text are kept in one
+// paragraph, joined by soft line breaks.
 func (m *Markdown) Blockquote(text string) *Markdown {
 	block := ast.NewBlockquote()
+	paragraph := ast.NewParagraph()
 	normalized := strings.ReplaceAll(text, "\r\n", "\n")
 	lines := strings.Split(normalized, "\n")
-	for _, line := range lines {
-		paragraph := ast.NewParagraph()
+	for i, line := range lines {
+		if i < len(lines)-1 {
+			line += "\n"
+		}
 		paragraph.AppendChild(paragraph, ast.NewString([]byte(line)))
-		block.AppendChild(block, paragraph)
 	}
+	block.AppendChild(block, paragraph)
 	m.appendBlock(block)
 	return m
 }
@@ -136,8 +120,17 @@ func (m *Markdown) HorizontalRule() *Markdown {
 	return m
 }
 
-// Table renders a markdown table using goldmark table AST nodes.
+// Table renders a markdown table using goldmark table AST nodes. Cell text
+// is escaped unless the builder was created with WithoutEscaping. Sets with
+// a Caption, HeaderGroups or Spans are written as HTML tables instead.
 func (m *Markdown) Table(set TableSet) *Markdown {
+	return m.table(set, false, nil)
+}
+
+// cellContent returns the inline nodes of the body cell at row and column.
+type cellContent func(row, column int, text string) []ast.Node
+
+func (m *Markdown) table(set TableSet, rawCells bool, content cellContent) *Markdown {
 	if err := set.ValidateColumns(); err != nil {
 		if m.err != nil {
 			m.err = fmt.Errorf("failed to validate columns: %w: %s", err, m.err)
@@ -150,42 +143,67 @@ func (m *Markdown) Table(set TableSet) *Markdown {
 	if len(set.Header) == 0 {
 		return m
 	}
+	if set.needsHTML() {
+		return m.tableHTML(set, rawCells, content)
+	}
 
 	table := tableast.NewTable()
 	table.Alignments = convertAlignments(set)
 
 	headerRow := tableast.NewTableRow(table.Alignments)
 	for idx, cellText := range set.Header {
-		cell := tableast.NewTableCell()
-		if idx < len(table.Alignments) {
-			cell.Alignment = table.Alignments[idx]
-		}
-		paragraph := ast.NewParagraph()
-		paragraph.AppendChild(paragraph, ast.NewString([]byte(cellText)))
-		cell.AppendChild(cell, paragraph)
-		headerRow.AppendChild(headerRow, cell)
+		headerRow.AppendChild(headerRow, tableCell(table.Alignments, idx, cellNode(cellText, rawCells)))
 	}
 	table.AppendChild(table, tableast.NewTableHeader(headerRow))
 
-	for _, row := range set.Rows {
+	if content == nil {
+		content = func(_, _ int, text string) []ast.Node {
+			return []ast.Node{cellNode(text, rawCells)}
+		}
+	}
+	for i, row := range set.Rows {
 		rowNode := tableast.NewTableRow(table.Alignments)
 		for idx, cellText := range row {
-			cell := tableast.NewTableCell()
-			if idx < len(table.Alignments) {
-				cell.Alignment = table.Alignments[idx]
-			}
-			paragraph := ast.NewParagraph()
-			paragraph.AppendChild(paragraph, ast.NewString([]byte(cellText)))
-			cell.AppendChild(cell, paragraph)
-			rowNode.AppendChild(rowNode, cell)
+			rowNode.AppendChild(rowNode, tableCell(table.Alignments, idx, content(i, idx, cellText)...))
 		}
 		table.AppendChild(table, rowNode)
 	}
+	if set.Footer != nil {
+		footer := tableast.NewTableRow(table.Alignments)
+		for idx, cellText := range set.Footer {
+			if cellText == "" {
+				footer.AppendChild(footer, tableCell(table.Alignments, idx))
+				continue
+			}
+			strong := ast.NewEmphasis(2)
+			strong.AppendChild(strong, cellNode(cellText, rawCells))
+			footer.AppendChild(footer, tableCell(table.Alignments, idx, strong))
+		}
+		table.AppendChild(table, footer)
+	}
 
 	m.appendBlock(table)
 	return m
 }
 
+func tableCell(alignments []tableast.Alignment, column int, content ...ast.Node) ast.Node {
+	cell := tableast.NewTableCell()
+	if column < len(alignments) {
+		cell.Alignment = alignments[column]
+	}
+	for _, node := range content {
+		cell.AppendChild(cell, node)
+	}
+	return cell
+}
+
+func cellNode(text string, raw bool) ast.Node {
+	if raw {
+		return rawNode(text)
+	}
+	return textNode(text)
+}
+
 func convertAlignments(set TableSet) []tableast.Alignment {
 	aligned := make([]tableast.Alignment, len(set.Header))
 	for i := 0; i < len(set.Header); i++ {
@@ -207,12 +225,98 @@ func convertAlignments(set TableSet) []tableast.Alignment {
 	return aligned
 }
 
-// CustomTable renders a table with optional formatting behaviors.
+// CustomTable renders a table with optional formatting behaviors. As with
+// Table, sets with a Caption, HeaderGroups or Spans are written as HTML
+// tables, which are never split by MaxTableWidth.
 func (m *Markdown) CustomTable(set TableSet, options TableOptions) *Markdown {
+	// Formatters and styles refer to columns by their original header.
+	formatters := make([]CellFormatter, len(set.Header))
+	styles := make([][]CellStyle, len(set.Header))
+	for i, header := range set.Header {
+		formatters[i] = options.Formatters[header]
+		styles[i] = options.Styles[header]
+	}
+	values := set.Rows
+	set.Rows = formatCells(set.Rows, formatters)
+	if set.Footer != nil {
+		set.Footer = formatCells([][]string{set.Footer}, formatters)[0]
+	}
+
 	if options.AutoFormatHeaders {
 		set.Header = formatHeaders(set.Header)
 	}
-	return m.Table(set)
+	if options.AutoWrapText {
+		width := options.MaxColumnWidth
+		if width <= 0 {
+			width = defaultMaxColumnWidth
+		}
+		// Escaped text turns line breaks into <br> itself; raw text must
+		// not hold line breaks, which would end the row.
+		raw := options.RawCells || m.options.rawText
+		lineBreak := "\n"
+		if raw {
+			lineBreak = "<br>"
+		}
+		set.Rows = fitRows(set.Rows, width, options.Truncate, raw, lineBreak)
+	}
+
+	// groups holds the columns of each table to write, nil for all of them.
+	groups := [][]int{nil}
+	if options.MaxTableWidth > 0 && !set.needsHTML() && set.ValidateColumns() == nil {
+		raw := options.RawCells || m.options.rawText
+		if split := columnGroups(set, options.MaxTableWidth, options.KeyColumns, raw); len(split) > 1 {
+			if options.TransposeWide {
+				transposed, err := set.Transpose()
+				if err != nil {
+					if m.err != nil {
+						m.err = fmt.Errorf("failed to transpose table: %w: %s", err, m.err)
+					} else {
+						m.err = fmt.Errorf("failed to transpose table: %w", err)
+					}
+					return m
+				}
+				if tableWidth(transposed, raw) <= options.MaxTableWidth {
+					return m.table(transposed, options.RawCells, transposedContent(set, values, styles, options.RawCells))
+				}
+			}
+			groups = split
+		}
+	}
+	for _, columns := range groups {
+		part := set
+		if columns != nil {
+			part = set.selectColumns(columns)
+		}
+		if len(options.Styles) == 0 {
+			m.table(part, options.RawCells, nil)
+			continue
+		}
+		m.table(part, options.RawCells, func(row, column int, text string) []ast.Node {
+			if columns != nil {
+				column = columns[column]
+			}
+			if column >= len(styles) || row >= len(values) || column >= len(values[row]) {
+				return []ast.Node{cellNode(text, options.RawCells)}
+			}
+			return styleCell(values[row][column], text, options.RawCells, styles[column])
+		})
+	}
+	return m
+}
+
+// formatCells returns a copy of rows with the formatter of each column
+// applied.
+func formatCells(rows [][]string, formatters []CellFormatter) [][]string {
+	formatted := make([][]string, len(rows))
+	for i, row := range rows {
+		formatted[i] = append([]string(nil), row...)
+		for j := range formatted[i] {
+			if j < len(formatters) && formatters[j] != nil {
+				formatted[i][j] = formatters[j](row[j])
+			}
+		}
+	}
+	return formatted
 }
 
 func formatHeaders(headers []string) []string {
@@ -0,0 +1,275 @@
+package markdown
+
+import (
+	"fmt"
+
+	"github.com/yuin/goldmark/ast"
+	tableast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// Include appends a copy of the content of sub below the current heading of
+// m. Headings of sub are shifted so that its H1 sits one level below the last
+// heading added to m, not counting included headings, capped at H6, and are
+// recorded for TableOfContents; headings written with Raw are copied as is.
+// A CustomTableOfContents of sub keeps listing the headings of sub, linked to
+// their anchors in m. Front matter of sub is dropped, and an error recorded
+// by sub is reported by m. sub is left unchanged and can be included more
+// than once.
+func (m *Markdown) Include(sub *Markdown) *Markdown {
+	if err := sub.Error(); err != nil {
+		m.addIncludeError(err)
+	}
+
+	// Parsed nodes of sub point into its source, which is appended to the
+	// source of m; their copies point into the appended part.
+	c := &nodeCopier{
+		source:   sub.source,
+		offset:   len(m.source),
+		headings: make(map[*ast.Heading]*ast.Heading),
+	}
+	var blocks []ast.Node
+	for child := sub.doc.FirstChild(); child != nil; child = child.NextSibling() {
+		if _, ok := child.(*frontMatterBlock); ok {
+			continue
+		}
+		block, err := c.copyTree(child)
+		if err != nil {
+			m.addIncludeError(err)
+			return m
+		}
+		blocks = append(blocks, block)
+	}
+	m.source = append(m.source, sub.source...)
+
+	shift := m.level
+	included := make(map[*ast.Heading]bool, len(c.headings))
+	r := m.newRenderer()
+	for _, block := range blocks {
+		_ = ast.Walk(block, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+			switch n := node.(type) {
+			case *tocBlock:
+				return ast.WalkSkipChildren, nil
+			case *ast.Heading:
+				if entering {
+					n.Level = min(n.Level+shift, 6)
+					included[n] = true
+					m.headers = append(m.headers, headerInfo{level: TableOfContentsDepth(n.Level), text: r.collectPlainText(n)})
+				}
+				return ast.WalkSkipChildren, nil
+			}
+			return ast.WalkContinue, nil
+		})
+		m.appendBlock(block)
+	}
+
+	// Tables of contents of sub list its headings, or the part of them they
+	// were limited to when sub included them itself.
+	for i, toc := range c.tocs {
+		original := c.originalTOCs[i]
+		toc.shift = original.shift + shift
+		toc.headings = included
+		if original.headings != nil {
+			toc.headings = make(map[*ast.Heading]bool, len(original.headings))
+			for h := range original.headings {
+				if copied, ok := c.headings[h]; ok {
+					toc.headings[copied] = true
+				}
+			}
+		}
+	}
+	return m
+}
+
+func (m *Markdown) addIncludeError(err error) {
+	if m.err != nil {
+		m.err = fmt.Errorf("failed to include markdown: %w: %s", err, m.err)
+	} else {
+		m.err = fmt.Errorf("failed to include markdown: %w", err)
+	}
+}
+
+// nodeCopier copies document nodes, moving the segments of parsed nodes by
+// offset for source appended behind another one.
+type nodeCopier struct {
+	source []byte
+	offset int
+	// headings maps the copied headings to their copies.
+	headings     map[*ast.Heading]*ast.Heading
+	tocs         []*tocBlock
+	originalTOCs []*tocBlock
+}
+
+// copyTree returns a copy of node and its children. Tables of contents are
+// copied without their content, which is rebuilt on render.
+func (c *nodeCopier) copyTree(node ast.Node) (ast.Node, error) {
+	copied, err := c.copyNode(node)
+	if err != nil {
+		return nil, err
+	}
+	if node.Type() == ast.TypeBlock {
+		copied.SetBlankPreviousLines(node.HasBlankPreviousLines())
+		if lines := node.Lines(); lines != nil && lines.Len() > 0 {
+			copied.SetLines(c.segments(lines))
+		}
+	}
+	for _, attr := range node.Attributes() {
+		copied.SetAttribute(attr.Name, attr.Value)
+	}
+	if _, ok := node.(*tocBlock); ok {
+		return copied, nil
+	}
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		copiedChild, err := c.copyTree(child)
+		if err != nil {
+			return nil, err
+		}
+		copied.AppendChild(copied, copiedChild)
+	}
+	return copied, nil
+}
+
+// copyNode returns a copy of node without its children.
+func (c *nodeCopier) copyNode(node ast.Node) (ast.Node, error) {
+	switch n := node.(type) {
+	case *ast.Paragraph:
+		return ast.NewParagraph(), nil
+	case *ast.TextBlock:
+		return ast.NewTextBlock(), nil
+	case *ast.Heading:
+		heading := ast.NewHeading(n.Level)
+		c.headings[n] = heading
+		return heading, nil
+	case *ast.Blockquote:
+		return ast.NewBlockquote(), nil
+	case *ast.List:
+		list := ast.NewList(n.Marker)
+		list.IsTight = n.IsTight
+		list.Start = n.Start
+		return list, nil
+	case *ast.ListItem:
+		return ast.NewListItem(n.Offset), nil
+	case *ast.FencedCodeBlock:
+		var info *ast.Text
+		if n.Info != nil {
+			info = ast.NewTextSegment(c.segment(n.Info.Segment))
+		}
+		return ast.NewFencedCodeBlock(info), nil
+	case *ast.CodeBlock:
+		return ast.NewCodeBlock(), nil
+	case *ast.HTMLBlock:
+		block := ast.NewHTMLBlock(n.HTMLBlockType)
+		if n.HasClosure() {
+			block.ClosureLine = c.segment(n.ClosureLine)
+		}
+		return block, nil
+	case *ast.ThematicBreak:
+		return ast.NewThematicBreak(), nil
+	case *ast.Text:
+		t := ast.NewTextSegment(c.segment(n.Segment))
+		t.SetSoftLineBreak(n.SoftLineBreak())
+		t.SetHardLineBreak(n.HardLineBreak())
+		t.SetRaw(n.IsRaw())
+		return t, nil
+	case *ast.String:
+		s := ast.NewString(n.Value)
+		s.SetRaw(n.IsRaw())
+		s.SetCode(n.IsCode())
+		return s, nil
+	case *ast.CodeSpan:
+		return ast.NewCodeSpan(), nil
+	case *ast.Emphasis:
+		return ast.NewEmphasis(n.Level), nil
+	case *ast.Link:
+		link := ast.NewLink()
+		link.Destination = n.Destination
+		link.Title = n.Title
+		return link, nil
+	case *ast.Image:
+		link := ast.NewLink()
+		link.Destination = n.Destination
+		link.Title = n.Title
+		return ast.NewImage(link), nil
+	case *ast.AutoLink:
+		// The label is a slice of the source, which gives its segment.
+		label := n.Label(c.source)
+		start := cap(c.source) - cap(label)
+		link := ast.NewAutoLink(n.AutoLinkType, ast.NewTextSegment(c.segment(text.NewSegment(start, start+len(label)))))
+		link.Protocol = n.Protocol
+		return link, nil
+	case *ast.RawHTML:
+		raw := ast.NewRawHTML()
+		raw.Segments = c.segments(n.Segments)
+		return raw, nil
+	case *tableast.Table:
+		table := tableast.NewTable()
+		table.Alignments = n.Alignments
+		return table, nil
+	case *tableast.TableHeader:
+		header := tableast.NewTableHeader(tableast.NewTableRow(n.Alignments))
+		header.Alignments = n.Alignments
+		return header, nil
+	case *tableast.TableRow:
+		return tableast.NewTableRow(n.Alignments), nil
+	case *tableast.TableCell:
+		cell := tableast.NewTableCell()
+		cell.Alignment = n.Alignment
+		return cell, nil
+	case *tableast.Strikethrough:
+		return tableast.NewStrikethrough(), nil
+	case *tableast.TaskCheckBox:
+		return tableast.NewTaskCheckBox(n.IsChecked), nil
+	case *literalBlock:
+		return newLiteralBlock(n.value), nil
+	case *codeBlockNode:
+		return newCodeBlockNode(n.language, n.value), nil
+	case *calloutBlock:
+		return newCalloutBlock(n.label), nil
+	case *detailsBlock:
+		return newDetailsBlock(n.summary), nil
+	case *tocBlock:
+		toc := newTOCBlock(n.options)
+		c.tocs = append(c.tocs, toc)
+		c.originalTOCs = append(c.originalTOCs, n)
+		return toc, nil
+	case *frontMatterBlock:
+		return newFrontMatterBlock(n.format, n.text), nil
+	case *highlightNode:
+		return newHighlightNode(), nil
+	case *lineBreakNode:
+		return newLineBreakNode(), nil
+	}
+	return nil, fmt.Errorf("%w: %s node", ErrUnsupportedNode, node.Kind())
+}
+
+func (c *nodeCopier) segment(segment text.Segment) text.Segment {
+	segment.Start += c.offset
+	segment.Stop += c.offset
+	return segment
+}
+
+func (c *nodeCopier) segments(segments *text.Segments) *text.Segments {
+	moved := text.NewSegments()
+	for i := 0; i < segments.Len(); i++ {
+		moved.Append(c.segment(segments.At(i)))
+	}
+	return moved
+}
+
+// Section adds a heading one level below the current heading of m and
+// includes the content fn builds below it, as Include does. fn receives a
+// new builder with the options of m, so its headings start at H1. The
+// current heading of m is kept, so consecutive sections are siblings.
+func (m *Markdown) Section(title string, fn func(s *Markdown)) *Markdown {
+	level := m.level
+	defer func() { m.level = level }()
+	m.addHeading(min(level+1, 6), title)
+	sub := &Markdown{
+		doc:     ast.NewDocument(),
+		dest:    m.dest,
+		headers: []headerInfo{},
+		options: m.options,
+	}
+	fn(sub)
+	return m.Include(sub)
+}
@@ -0,0 +1,252 @@
+package markdown
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownInclude(t *testing.T) {
+	t.Parallel()
+
+	service := NewMarkdown(io.Discard, WithLineEnding("\n")).
+		H1("API").
+		PlainText("Healthy *enough*.").
+		H2("Latency").
+		Table(TableSet{Header: []string{"p50", "p99"}, Rows: [][]string{{"10ms", "80ms"}}})
+
+	md := NewMarkdown(io.Discard, WithLineEnding("\n")).
+		H1("Report").
+		H2("Services").
+		Include(service).
+		Include(service).
+		TableOfContents(TableOfContentsDepthH3)
+
+	want := strings.Join([]string{
+		"# Report",
+		"",
+		"## Services",
+		"",
+		"### API",
+		"",
+		`Healthy \*enough\*.`,
+		"",
+		"#### Latency",
+		"",
+		"| p50  | p99  |",
+		"| ---- | ---- |",
+		"| 10ms | 80ms |",
+		"",
+		"### API",
+		"",
+		`Healthy \*enough\*.`,
+		"",
+		"#### Latency",
+		"",
+		"| p50  | p99  |",
+		"| ---- | ---- |",
+		"| 10ms | 80ms |",
+		"",
+		"- [Report](#report)",
+		"  - [Services](#services)",
+		"    - [API](#api)",
+		"    - [API](#api-1)",
+		"",
+	}, "\n")
+	if got := md.String(); got != want {
+		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+	}
+	if got := service.String(); !strings.HasPrefix(got, "# API\n") {
+		t.Fatalf("included builder was changed: %q", got)
+	}
+}
+
+func TestMarkdownIncludeParsed(t *testing.T) {
+	t.Parallel()
+
+	parent, err := Parse(io.Discard, []byte("# Changelog\n\nSee [docs](https://example.com).\n"), WithLineEnding("\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	child, err := Parse(io.Discard, []byte("---\ntitle: v2\n---\n# v2 `beta`\n\n```go\nfmt.Println()\n```\n\n<https://example.com>\n"), WithLineEnding("\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parent.Include(child).H5("Deep").Include(child)
+
+	want := strings.Join([]string{
+		"# Changelog",
+		"",
+		"See [docs](https://example.com).",
+		"",
+		"## v2 `beta`",
+		"",
+		"```go",
+		"fmt.Println()",
+		"```",
+		"",
+		"<https://example.com>",
+		"",
+		"##### Deep",
+		"",
+		"###### v2 `beta`",
+		"",
+		"```go",
+		"fmt.Println()",
+		"```",
+		"",
+		"<https://example.com>",
+		"",
+	}, "\n")
+	if got := parent.String(); got != want {
+		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+	}
+	if got := parent.headers[1].text; got != "v2 beta" {
+		t.Fatalf("unexpected header text: %q", got)
+	}
+}
+
+func TestMarkdownSection(t *testing.T) {
+	t.Parallel()
+
+	md := NewMarkdown(io.Discard, WithLineEnding("\n")).
+		H1("Report").
+		Section("Services", func(s *Markdown) {
+			s.PlainText("All services.").
+				Section("API", func(s *Markdown) {
+					s.BulletList("up")
+				})
+		}).
+		BulletListFunc(func(l *ListBuilder) {
+			l.ItemFunc(func(item *Markdown) {
+				item.PlainText("Nested").Section("Item", func(s *Markdown) {
+					s.PlainText("Body.")
+				})
+			})
+		}).
+		TableOfContents(TableOfContentsDepthH6)
+
+	want := strings.Join([]string{
+		"# Report",
+		"",
+		"## Services",
+		"",
+		"All services.",
+		"",
+		"### API",
+		"",
+		"- up",
+		"",
+		"<!-- -->",
+		"",
+		"- Nested",
+		"",
+		"  ## Item",
+		"",
+		"  Body.",
+		"",
+		"- [Report](#report)",
+		"  - [Services](#services)",
+		"    - [API](#api)",
+		"  - [Item](#item)",
+		"",
+	}, "\n")
+	if got := md.String(); got != want {
+		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+	}
+
+	err := NewMarkdown(io.Discard).Section("Broken", func(s *Markdown) {
+		s.Table(TableSet{Header: []string{"a"}, Rows: [][]string{{"1", "2"}}})
+	}).Error()
+	if !errors.Is(err, ErrMismatchColumn) {
+		t.Fatalf("expected ErrMismatchColumn, got %v", err)
+	}
+}
+
+func TestMarkdownIncludeTableOfContents(t *testing.T) {
+	t.Parallel()
+
+	service := NewMarkdown(io.Discard, WithLineEnding("\n")).
+		CustomTableOfContents(TableOfContentsOptions{Title: "Contents", MaxDepth: TableOfContentsDepthH2}).
+		H1("API").
+		H2("Latency").
+		H3("Hidden")
+
+	parent, err := Parse(io.Discard, []byte("# Report\n\n## API"), WithLineEnding("\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parent.H2("Services").Include(service)
+
+	want := strings.Join([]string{
+		"# Report",
+		"",
+		"## API",
+		"",
+		"## Services",
+		"",
+		"#### Contents",
+		"",
+		"- [API](#api-1)",
+		"  - [Latency](#latency)",
+		"",
+		"### API",
+		"",
+		"#### Latency",
+		"",
+		"##### Hidden",
+		"",
+	}, "\n")
+	if got := parent.String(); got != want {
+		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+	}
+	if got := service.String(); !strings.Contains(got, "- [API](#api)\n") {
+		t.Fatalf("included builder was changed: %q", got)
+	}
+
+	// A table of contents keeps its headings through nested sections.
+	nested := NewMarkdown(io.Discard, WithLineEnding("\n")).Section("Outer", func(s *Markdown) {
+		s.H1("Intro").Section("Inner", func(s *Markdown) {
+			s.CustomTableOfContents(TableOfContentsOptions{}).H1("Deep")
+		})
+	})
+	want = "# Outer\n\n## Intro\n\n### Inner\n\n- [Deep](#deep)\n\n#### Deep\n"
+	if got := nested.String(); got != want {
+		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+func TestMarkdownIncludeKeepsNodes(t *testing.T) {
+	t.Parallel()
+
+	build := func(m *Markdown) *Markdown {
+		return m.Note("careful").
+			Paragraph(func(p *Inline) { p.Highlight("marked") }).
+			Details("More", "Hidden text").
+			CodeBlocks(SyntaxHighlightGo, "x := 1")
+	}
+	// The child's flavor doesn't carry over: included content is written in
+	// the flavor of the parent.
+	child := build(NewMarkdown(io.Discard, WithFlavor(FlavorObsidian)))
+	md := NewMarkdown(io.Discard).Include(child)
+	direct := build(NewMarkdown(io.Discard))
+
+	html, err := md.HTML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := direct.HTML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if html != want {
+		t.Fatalf("unexpected html output\nwant: %q\ngot:  %q", want, html)
+	}
+	if !strings.Contains(html, `<div class="markdown-alert markdown-alert-note">`) {
+		t.Fatalf("included note is not an alert: %q", html)
+	}
+	if got, want := md.String(), direct.String(); got != want {
+		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+	}
+}
@@ -1,14 +1,98 @@
 package markdown
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	// ErrMismatchColumn is returned when the number of columns in the record doesn't match the header.
 	ErrMismatchColumn = errors.New("number of columns in the record doesn't match the header")
+	// ErrInvalidHeadingLevel is returned when a heading level is outside 1 to 6.
+	ErrInvalidHeadingLevel = errors.New("heading level must be between 1 and 6")
+	// ErrInvalidListStart is returned when an ordered list starts outside 0 to 999999999.
+	ErrInvalidListStart = errors.New("ordered list start must be between 0 and 999999999")
 	// ErrInitMarkdownIndex is returned when the index can't be initialized.
 	ErrInitMarkdownIndex = errors.New("markdown index can't be initialized")
 	// ErrCreateMarkdownIndex is returned when the index can't be created.
 	ErrCreateMarkdownIndex = errors.New("markdown index can't be created")
 	// ErrWriteMarkdownIndex is returned when the index can't be written.
 	ErrWriteMarkdownIndex = errors.New("markdown index can't be written")
+	// ErrInvalidEncoding is returned when markdown source isn't valid UTF-8.
+	ErrInvalidEncoding = errors.New("markdown source is not valid UTF-8")
+	// ErrParseMarkdown is returned when markdown source can't be parsed into a document.
+	ErrParseMarkdown = errors.New("markdown source can't be parsed")
+	// ErrUnsupportedNode is returned when Include meets a node it can't copy.
+	ErrUnsupportedNode = errors.New("node can't be included")
+	// ErrNotStruct is returned when TableOf is given values other than structs or struct pointers.
+	ErrNotStruct = errors.New("table rows must be structs or struct pointers")
+	// ErrInvalidTag is returned when an md struct tag can't be parsed.
+	ErrInvalidTag = errors.New("invalid md struct tag")
+	// ErrInvalidJSONTable is returned when JSON input isn't an array of objects or an array of arrays.
+	ErrInvalidJSONTable = errors.New("json table must be an array of objects or an array of arrays")
+	// ErrUnknownColumn is returned when a selected column isn't in the header.
+	ErrUnknownColumn = errors.New("column is not in the table header")
+	// ErrPivotConflict is returned when Pivot finds several values for a cell that can't be summed.
+	ErrPivotConflict = errors.New("pivot cell has several values that aren't numbers")
+	// ErrInvalidSpan is returned when a column group or cell span doesn't fit the table.
+	ErrInvalidSpan = errors.New("span doesn't fit the table")
+	// ErrNoTable is returned when markdown source holds no table.
+	ErrNoTable = errors.New("markdown source has no table")
+	// ErrTableWriterClosed is returned when a row is written after a TableWriter is closed.
+	ErrTableWriterClosed = errors.New("table writer is closed")
+	// ErrRegionNotFound is returned when a document has no managed region with a given name.
+	ErrRegionNotFound = errors.New("managed region not found")
+	// ErrUnterminatedRegion is returned when a managed region has no end marker.
+	ErrUnterminatedRegion = errors.New("managed region has no end marker")
+	// ErrInvalidRegionMarker is returned when a managed region is opened and closed on one line.
+	ErrInvalidRegionMarker = errors.New("managed region markers must be on separate lines")
+	// ErrRegionDrift is returned when managed regions don't hold the output of their builders.
+	ErrRegionDrift = errors.New("managed regions are out of date")
+	// ErrInvalidFrontMatter is returned when front matter can't be encoded or decoded.
+	ErrInvalidFrontMatter = errors.New("invalid front matter")
+	// ErrUnsupportedConstruct is returned when a construct has no syntax in the target flavor.
+	ErrUnsupportedConstruct = errors.New("construct is not supported by the markdown flavor")
 )
+
+// ColumnMismatchError reports a table row whose number of columns doesn't
+// match the header.
+type ColumnMismatchError struct {
+	// Row is the zero-based index of the row in TableSet.Rows, or the
+	// number of rows for the footer.
+	Row int
+	// Footer reports whether the footer is the mismatched row.
+	Footer bool
+	// Columns is the number of columns of the row.
+	Columns int
+	// Want is the number of columns of the header.
+	Want int
+}
+
+func (e *ColumnMismatchError) Error() string {
+	if e.Footer {
+		return fmt.Sprintf("%s: footer has %d columns, want %d", ErrMismatchColumn, e.Columns, e.Want)
+	}
+	return fmt.Sprintf("%s: row %d has %d columns, want %d", ErrMismatchColumn, e.Row+1, e.Columns, e.Want)
+}
+
+// Unwrap returns ErrMismatchColumn.
+func (e *ColumnMismatchError) Unwrap() error {
+	return ErrMismatchColumn
+}
+
+// RegionDriftError reports the managed regions whose content differs from
+// the output of their builders.
+type RegionDriftError struct {
+	// Regions are the names of the stale regions in document order.
+	Regions []string
+}
+
+func (e *RegionDriftError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrRegionDrift, strings.Join(e.Regions, ", "))
+}
+
+// Unwrap returns ErrRegionDrift.
+func (e *RegionDriftError) Unwrap() error {
+	return ErrRegionDrift
+}
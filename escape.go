@@ -0,0 +1,134 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+)
+
+// escapeContext describes where a text value is written, which decides the
+// characters that need a backslash to keep their literal meaning.
+type escapeContext int
+
+const (
+	// escapeParagraph is running text in paragraphs, list items and quotes.
+	escapeParagraph escapeContext = iota
+	// escapeTableCell is a pipe table cell, where pipes and line breaks matter.
+	escapeTableCell
+	// escapeHeading is ATX heading text, which can't span lines.
+	escapeHeading
+	// escapeLinkText is the bracketed text of a link or image.
+	escapeLinkText
+)
+
+// escapeText escapes Markdown syntax in text for the given context. lineStart
+// reports whether text begins a line, where block markers such as "#", ">"
+// or "1." would otherwise start a new construct. Headings only begin a line
+// in setext style.
+func escapeText(text string, ctx escapeContext, lineStart bool) string {
+	lines := splitLines(text)
+	var buf strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			switch ctx {
+			case escapeTableCell:
+				buf.WriteString("<br>")
+			case escapeHeading:
+				buf.WriteString(" ")
+			default:
+				buf.WriteString("\n")
+			}
+		}
+		atStart := (ctx == escapeParagraph && (lineStart || i > 0)) || (ctx == escapeHeading && lineStart && i == 0)
+		buf.WriteString(escapeLine(line, atStart, ctx == escapeTableCell))
+	}
+	if ctx == escapeHeading {
+		return escapeClosingHashes(buf.String())
+	}
+	return buf.String()
+}
+
+func escapeLine(line string, lineStart, cell bool) string {
+	runes := []rune(line)
+	var buf strings.Builder
+	i := 0
+	if lineStart {
+		// Leading whitespace could indent the line into a code block, so it
+		// is written as character references.
+		for ; i < len(runes) && (runes[i] == ' ' || runes[i] == '\t'); i++ {
+			if runes[i] == ' ' {
+				buf.WriteString("&#32;")
+			} else {
+				buf.WriteString("&#9;")
+			}
+		}
+		if i < len(runes) {
+			switch runes[i] {
+			case '#', '>', '-', '+', '=':
+				buf.WriteRune('\\')
+				buf.WriteRune(runes[i])
+				i++
+			default:
+				j := i
+				for j < len(runes) && runes[j] >= '0' && runes[j] <= '9' {
+					j++
+				}
+				if j > i && j < len(runes) && (runes[j] == '.' || runes[j] == ')') {
+					buf.WriteString(string(runes[i:j]))
+					buf.WriteRune('\\')
+					buf.WriteRune(runes[j])
+					i = j + 1
+				}
+			}
+		}
+	}
+	for ; i < len(runes); i++ {
+		r := runes[i]
+		switch r {
+		case '\\', '*', '`', '[', ']', '<', '~':
+			buf.WriteRune('\\')
+		case '_':
+			// Underscores inside words can't open or close emphasis.
+			if i == 0 || i == len(runes)-1 || !isWordRune(runes[i-1]) || !isWordRune(runes[i+1]) {
+				buf.WriteRune('\\')
+			}
+		case '&':
+			// Only escape what could be read as an entity reference.
+			if i+1 < len(runes) && (runes[i+1] == '#' || unicode.IsLetter(runes[i+1])) {
+				buf.WriteRune('\\')
+			}
+		case '|':
+			if cell {
+				buf.WriteRune('\\')
+			}
+		}
+		buf.WriteRune(r)
+	}
+	// Two trailing spaces would end the line with a hard line break.
+	escaped := buf.String()
+	if strings.HasSuffix(escaped, "  ") {
+		return escaped[:len(escaped)-1] + "&#32;"
+	}
+	return escaped
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// escapeClosingHashes keeps a trailing run of "#" from being read as the
+// optional closing sequence of an ATX heading.
+func escapeClosingHashes(text string) string {
+	trimmed := strings.TrimRight(text, "#")
+	if trimmed == text || (trimmed != "" && !strings.HasSuffix(trimmed, " ")) {
+		return text
+	}
+	return trimmed + `\` + text[len(trimmed):]
+}
+
+// escapeLinkDestination returns dest in the backslash-escaped source form
+// goldmark stores in ast.Link.Destination, so builder and parsed links are
+// rendered alike.
+func escapeLinkDestination(dest string) string {
+	dest = strings.NewReplacer("\r", "%0D", "\n", "%0A").Replace(dest)
+	return strings.NewReplacer(`\`, `\\`, "<", `\<`, ">", `\>`, "(", `\(`, ")", `\)`).Replace(dest)
+}
@@ -0,0 +1,137 @@
+package markdown
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+func TestEscapeText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		text      string
+		ctx       escapeContext
+		lineStart bool
+		want      string
+	}{
+		{"emphasis", "a *b* c", escapeParagraph, false, `a \*b\* c`},
+		{"intraword underscore", "snake_case _x_", escapeParagraph, false, `snake_case \_x\_`},
+		{"heading marker", "# not a heading", escapeParagraph, true, `\# not a heading`},
+		{"list marker", "- item", escapeParagraph, true, `\- item`},
+		{"ordered marker", "2024. year", escapeParagraph, true, `2024\. year`},
+		{"marker mid line", "a - b", escapeParagraph, false, "a - b"},
+		{"later lines", "a\n> b", escapeParagraph, false, "a\n\\> b"},
+		{"leading whitespace", "  \tcode\n    x", escapeParagraph, true, "&#32;&#32;&#9;code\n&#32;&#32;&#32;&#32;x"},
+		{"leading whitespace mid line", "a\n  b", escapeParagraph, false, "a\n&#32;&#32;b"},
+		{"trailing spaces", "a  \nb   ", escapeParagraph, false, "a &#32;\nb  &#32;"},
+		{"html and entities", "<b> &amp; & x", escapeParagraph, false, `\<b> \&amp; & x`},
+		{"links", "[x](y) ~~z~~", escapeLinkText, false, `\[x\](y) \~\~z\~\~`},
+		{"table cell", "a|b\nc", escapeTableCell, false, `a\|b<br>c`},
+		{"heading closing hashes", "Issue #", escapeHeading, false, `Issue \#`},
+		{"heading hash inside word", "C#", escapeHeading, false, "C#"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeText(tt.text, tt.ctx, tt.lineStart); got != tt.want {
+				t.Fatalf("escapeText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapedTextRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	values := []string{
+		"* star",
+		"1) one",
+		"+ plus | pipe",
+		"`code` and \\ backslash",
+		"<https://example.com>",
+		"_under_ and __double__",
+		"&copy; ~~strike~~ ![img](x.png)",
+		"    code",
+		"\tcode",
+	}
+	for _, value := range values {
+		md := NewMarkdown(io.Discard)
+		md.PlainText(value)
+		parsed, err := Parse(io.Discard, []byte(md.String()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		para, ok := parsed.doc.FirstChild().(*ast.Paragraph)
+		if !ok {
+			t.Fatalf("%q did not render as a single paragraph: %q", value, md.String())
+		}
+		if got := parsed.newRenderer().collectPlainText(para); got != value {
+			t.Fatalf("round trip of %q produced %q", value, got)
+		}
+	}
+
+	md := NewMarkdown(io.Discard).PlainText("a  \nb")
+	parsed, err := Parse(io.Discard, []byte(md.String()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = ast.Walk(parsed.doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if text, ok := node.(*ast.Text); ok && entering && text.HardLineBreak() {
+			t.Fatalf("trailing spaces produced a hard line break: %q", md.String())
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+func TestMarkdownEscaping(t *testing.T) {
+	t.Parallel()
+
+	lf := lineFeed()
+
+	t.Run("escaped by default", func(t *testing.T) {
+		md := NewMarkdown(io.Discard)
+		md.H2("Issue #").
+			Table(TableSet{Header: []string{"Message"}, Rows: [][]string{{"fix a|b"}}}).
+			Raw(Bold("trusted")).
+			Paragraph(func(p *Inline) {
+				p.Link("[docs]", "https://example.com/a (b)").Text(" ").Raw("*raw*")
+			})
+		want := strings.Join([]string{
+			`## Issue \#`,
+			"",
+			"| Message  |",
+			"| -------- |",
+			`| fix a\|b |`,
+			"",
+			"**trusted**",
+			"",
+			`[\[docs\]](<https://example.com/a \(b\)>) *raw*`,
+			"",
+		}, lf)
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("without escaping", func(t *testing.T) {
+		md := NewMarkdown(io.Discard, WithoutEscaping())
+		md.PlainText(Bold("bold") + " " + Code("code"))
+		want := "**bold** `code`" + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("raw table cells", func(t *testing.T) {
+		md := NewMarkdown(io.Discard)
+		md.CustomTable(TableSet{Header: []string{"Link"}, Rows: [][]string{{Link("a", "b")}}}, TableOptions{RawCells: true})
+		want := "| Link   |" + lf + "| ------ |" + lf + "| [a](b) |" + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+}
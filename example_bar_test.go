@@ -58,6 +58,7 @@ func ExampleMarkdown_Table_bars() {
 
 	// Output:
 	// ## Daily Bars
+	//
 	// | Day        | Open   | High   | Low    | Close  | Volume  | Trades | VWAP   |
 	// | ---------- | ------ | ------ | ------ | ------ | ------- | ------ | ------ |
 	// | 2024-10-01 | 101.25 | 105.50 | 100.90 | 104.20 | 1200345 | 3456   | 103.45 |
@@ -68,3 +69,43 @@ func ExampleMarkdown_Table_bars() {
 	// | 2024-10-06 | 107.10 | 108.75 | 106.40 | 108.20 | 876540  | 2400   | 107.95 |
 	// | 2024-10-07 | 108.20 | 110.15 | 107.95 | 109.60 | 1132050 | 3250   | 109.05 |
 }
+
+// TaggedBar is an aggregate of trades described by md struct tags.
+type TaggedBar struct {
+	Timestamp  time.Time `md:"Day,format=2006-01-02"`
+	Open       float64   `md:",align=right,format=%.2f"`
+	Close      float64   `md:",align=right,format=%.2f"`
+	Volume     uint64    `md:",align=right"`
+	TradeCount uint64    `md:"Trades,align=right"`
+}
+
+// ExampleTableOf shows how struct tags replace the conversion of values to rows.
+func ExampleTableOf() {
+	bars := []TaggedBar{
+		{Timestamp: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), Open: 101.25, Close: 104.20, Volume: 1200345, TradeCount: 3456},
+		{Timestamp: time.Date(2024, 10, 2, 0, 0, 0, 0, time.UTC), Open: 104.20, Close: 105.10, Volume: 980456, TradeCount: 2980},
+	}
+
+	set, err := TableOf(bars)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error building table: %v\n", err)
+		return
+	}
+
+	md := NewMarkdown(os.Stdout)
+	md.H2("Daily Bars")
+	md.Table(set)
+
+	if err := md.Build(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error building markdown: %v\n", err)
+		return
+	}
+
+	// Output:
+	// ## Daily Bars
+	//
+	// | Day        |   Open |  Close |  Volume | Trades |
+	// | ---------- | -----: | -----: | ------: | -----: |
+	// | 2024-10-01 | 101.25 | 104.20 | 1200345 |   3456 |
+	// | 2024-10-02 | 104.20 | 105.10 |  980456 |   2980 |
+}
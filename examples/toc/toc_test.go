@@ -23,7 +23,9 @@ func TestTOCRendering(t *testing.T) {
 		Build()
 
 	expected := `# Guide to markdown
+
 Markdown built through goldmark AST.
+
 | Feature | Description                                   |
 | ------- | --------------------------------------------- |
 | TOC     | Generate nested table of contents             |
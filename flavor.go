@@ -0,0 +1,150 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// Flavor is the Markdown dialect a document is rendered for. Alerts,
+// highlights and details blocks are written with the flavor's own syntax,
+// or with the closest fallback when the flavor has none.
+type Flavor int
+
+const (
+	// FlavorGitHub targets GitHub Flavored Markdown. This is the default.
+	// Highlights are written as <mark> HTML.
+	FlavorGitHub Flavor = iota
+	// FlavorGitLab targets GitLab Flavored Markdown, which reads GitHub
+	// alert syntax. Highlights are written as <mark> HTML.
+	FlavorGitLab
+	// FlavorCommonMark targets plain CommonMark. Alerts are written as
+	// blockquotes starting with a bold label, and highlights as <mark> HTML.
+	FlavorCommonMark
+	// FlavorObsidian targets Obsidian, using its callouts, foldable callouts
+	// for details blocks and == highlights.
+	FlavorObsidian
+	// FlavorMkDocs targets MkDocs Material with the admonition, details and
+	// mark extensions, using !!! admonitions, ??? details and == highlights.
+	FlavorMkDocs
+)
+
+// String returns the name of the flavor.
+func (f Flavor) String() string {
+	switch f {
+	case FlavorGitHub:
+		return "GitHub"
+	case FlavorGitLab:
+		return "GitLab"
+	case FlavorCommonMark:
+		return "CommonMark"
+	case FlavorObsidian:
+		return "Obsidian"
+	case FlavorMkDocs:
+		return "MkDocs"
+	default:
+		return fmt.Sprintf("Flavor(%d)", int(f))
+	}
+}
+
+// WithFlavor sets the Markdown dialect the document is rendered for.
+func WithFlavor(flavor Flavor) Option {
+	return func(o *options) {
+		o.flavor = flavor
+	}
+}
+
+// WithStrictFlavor makes Build fail with ErrUnsupportedConstruct instead of
+// writing a fallback when the document holds a construct the flavor has no
+// syntax for.
+func WithStrictFlavor() Option {
+	return func(o *options) {
+		o.strictFlavor = true
+	}
+}
+
+// degrade records that construct was written with a fallback syntax.
+func (r *markdownRenderer) degrade(construct string) {
+	for _, seen := range r.degraded {
+		if seen == construct {
+			return
+		}
+	}
+	r.degraded = append(r.degraded, construct)
+}
+
+func (r *markdownRenderer) flavorError() error {
+	if !r.strictFlavor || len(r.degraded) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s in %s", ErrUnsupportedConstruct, strings.Join(r.degraded, ", "), r.flavor)
+}
+
+func (r *markdownRenderer) renderCalloutLines(c *calloutBlock) []string {
+	switch r.flavor {
+	case FlavorObsidian:
+		lines := []string{fmt.Sprintf("> [!%s]", strings.ToLower(c.label))}
+		return append(lines, r.renderQuotedLines(c)...)
+	case FlavorMkDocs:
+		return append([]string{"!!! " + strings.ToLower(c.label)}, r.indentBlockLines(c)...)
+	case FlavorCommonMark:
+		r.degrade("alert")
+		lines := []string{fmt.Sprintf("> **%s**", calloutTitle(c.label))}
+		if !r.compactBlocks {
+			lines = append(lines, ">")
+		}
+		return append(lines, r.renderQuotedLines(c)...)
+	default:
+		lines := []string{fmt.Sprintf("> [!%s]  ", c.label)}
+		return append(lines, r.renderQuotedLines(c)...)
+	}
+}
+
+func (r *markdownRenderer) renderDetailsLines(d *detailsBlock) []string {
+	switch r.flavor {
+	case FlavorObsidian:
+		lines := []string{"> [!note]- " + d.summary}
+		return append(lines, r.renderQuotedLines(d)...)
+	case FlavorMkDocs:
+		title := strings.ReplaceAll(d.summary, `"`, "&quot;")
+		return append([]string{fmt.Sprintf("??? note \"%s\"", title)}, r.indentBlockLines(d)...)
+	default:
+		if r.flavor == FlavorCommonMark {
+			r.degrade("details")
+		}
+		lines := []string{fmt.Sprintf("<details><summary>%s</summary>", d.summary)}
+		lines = append(lines, r.collectBlockLines(d, false)...)
+		return append(lines, "</details>")
+	}
+}
+
+// indentBlockLines renders the children of node indented by four spaces, as
+// the body of an admonition.
+func (r *markdownRenderer) indentBlockLines(node ast.Node) []string {
+	var lines []string
+	for _, line := range r.collectBlockLines(node, !r.compactBlocks) {
+		if line == "" {
+			lines = append(lines, "")
+			continue
+		}
+		lines = append(lines, "    "+line)
+	}
+	return lines
+}
+
+func (r *markdownRenderer) highlightDelimiters() (string, string) {
+	switch r.flavor {
+	case FlavorObsidian, FlavorMkDocs:
+		return "==", "=="
+	default:
+		r.degrade("highlight")
+		return "<mark>", "</mark>"
+	}
+}
+
+// calloutTitle returns the display title of an alert label such as "NOTE".
+func calloutTitle(label string) string {
+	label = strings.ToLower(label)
+	return strings.ToUpper(label[:1]) + label[1:]
+}
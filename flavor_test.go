@@ -0,0 +1,125 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFlavor(t *testing.T) {
+	t.Parallel()
+
+	build := func(flavor Flavor) *Markdown {
+		return NewMarkdown(io.Discard, WithLineEnding("\n"), WithFlavor(flavor)).
+			Note("Read this").
+			Paragraph(func(p *Inline) { p.Highlight("marked") }).
+			Details("More", "Hidden text")
+	}
+
+	tests := []struct {
+		flavor Flavor
+		want   []string
+	}{
+		{
+			flavor: FlavorGitHub,
+			want: []string{
+				"> [!NOTE]  ", "> Read this", "",
+				"<mark>marked</mark>", "",
+				"<details><summary>More</summary>", "Hidden text", "</details>",
+			},
+		},
+		{
+			flavor: FlavorGitLab,
+			want: []string{
+				"> [!NOTE]  ", "> Read this", "",
+				"<mark>marked</mark>", "",
+				"<details><summary>More</summary>", "Hidden text", "</details>",
+			},
+		},
+		{
+			flavor: FlavorCommonMark,
+			want: []string{
+				"> **Note**", ">", "> Read this", "",
+				"<mark>marked</mark>", "",
+				"<details><summary>More</summary>", "Hidden text", "</details>",
+			},
+		},
+		{
+			flavor: FlavorObsidian,
+			want: []string{
+				"> [!note]", "> Read this", "",
+				"==marked==", "",
+				"> [!note]- More", "> Hidden text",
+			},
+		},
+		{
+			flavor: FlavorMkDocs,
+			want: []string{
+				"!!! note", "    Read this", "",
+				"==marked==", "",
+				`??? note "More"`, "    Hidden text",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flavor.String(), func(t *testing.T) {
+			t.Parallel()
+
+			want := strings.Join(tt.want, "\n") + "\n"
+			if got := build(tt.flavor).String(); got != want {
+				t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+			}
+		})
+	}
+}
+
+func TestStrictFlavor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unsupported construct", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		err := NewMarkdown(&buf, WithFlavor(FlavorCommonMark), WithStrictFlavor()).
+			Note("Read this").
+			Build()
+		if !errors.Is(err, ErrUnsupportedConstruct) {
+			t.Fatalf("expected ErrUnsupportedConstruct, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "alert in CommonMark") {
+			t.Fatalf("expected the construct and flavor in the error, got %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Fatalf("expected nothing written, got %q", buf.String())
+		}
+	})
+
+	t.Run("supported constructs", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		err := NewMarkdown(&buf, WithFlavor(FlavorObsidian), WithStrictFlavor()).
+			Note("Read this").
+			Paragraph(func(p *Inline) { p.Highlight("marked") }).
+			Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestDetailsHTML(t *testing.T) {
+	t.Parallel()
+
+	got, err := NewMarkdown(io.Discard).Details("More *info*", "Hidden text").HTML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "<details><summary>More <em>info</em></summary>\n<p>Hidden text</p>\n</details>\n"
+	if got != want {
+		t.Fatalf("unexpected html output\nwant: %q\ngot:  %q", want, got)
+	}
+}
@@ -0,0 +1,176 @@
+package markdown
+
+import (
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// CellFormatter rewrites the value of a table cell before it is rendered.
+type CellFormatter func(value string) string
+
+// FormatNumber writes numbers with a fixed number of decimals. Values that
+// aren't numbers are left unchanged.
+func FormatNumber(decimals int) CellFormatter {
+	return numberFormatter(func(v float64, s numberStyle) string {
+		return numberStyle{decimals: decimals, percent: s.percent}.format(v)
+	})
+}
+
+// FormatThousands writes numbers with a fixed number of decimals and comma
+// thousands separators.
+func FormatThousands(decimals int) CellFormatter {
+	return numberFormatter(func(v float64, s numberStyle) string {
+		return numberStyle{decimals: decimals, grouped: true, percent: s.percent}.format(v)
+	})
+}
+
+// FormatPercent writes fractions such as 0.125 as percentages such as
+// "12.5%" with a fixed number of decimals.
+func FormatPercent(decimals int) CellFormatter {
+	return numberFormatter(func(v float64, s numberStyle) string {
+		if s.percent {
+			return numberStyle{decimals: decimals, percent: true}.format(v)
+		}
+		return numberStyle{decimals: decimals, percent: true}.format(v * 100)
+	})
+}
+
+// FormatDuration writes numbers counting unit as durations, so that 1500
+// with time.Millisecond becomes "1.5s". Values already written as Go
+// durations are normalized.
+func FormatDuration(unit time.Duration) CellFormatter {
+	return func(value string) string {
+		if d, err := time.ParseDuration(strings.TrimSpace(value)); err == nil {
+			return d.String()
+		}
+		v, _, ok := parseNumber(value)
+		if !ok {
+			return value
+		}
+		return time.Duration(math.Round(v * float64(unit))).String()
+	}
+}
+
+// FormatBytes writes byte counts with binary units, such as "1.5 KiB".
+func FormatBytes() CellFormatter {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	return numberFormatter(func(v float64, _ numberStyle) string {
+		unit := 0
+		for math.Abs(v) >= 1024 && unit < len(units)-1 {
+			v /= 1024
+			unit++
+		}
+		if unit == 0 {
+			return strconv.FormatFloat(v, 'f', -1, 64) + " B"
+		}
+		return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64) + " " + units[unit]
+	})
+}
+
+func numberFormatter(format func(float64, numberStyle) string) CellFormatter {
+	return func(value string) string {
+		v, style, ok := parseNumber(value)
+		if !ok {
+			return value
+		}
+		return format(v, style)
+	}
+}
+
+// CellStyle decorates the table cells whose value matches a condition. The
+// condition sees the value before formatting.
+type CellStyle struct {
+	// When reports whether the style applies to a cell value. Nil matches
+	// every non-empty cell.
+	When func(value string) bool
+	// Bold writes the cell in bold.
+	Bold bool
+	// Prefix is written before the value, such as an emoji.
+	Prefix string
+	// BadgeColor replaces the value with a shields.io badge of that colour,
+	// such as "red" or "brightgreen".
+	BadgeColor string
+}
+
+// ValueAbove matches cells holding a number greater than threshold.
+func ValueAbove(threshold float64) func(string) bool {
+	return func(value string) bool {
+		v, _, ok := parseNumber(value)
+		return ok && v > threshold
+	}
+}
+
+// ValueBelow matches cells holding a number less than threshold.
+func ValueBelow(threshold float64) func(string) bool {
+	return func(value string) bool {
+		v, _, ok := parseNumber(value)
+		return ok && v < threshold
+	}
+}
+
+// ValueEquals matches cells holding one of values, ignoring case and
+// surrounding spaces.
+func ValueEquals(values ...string) func(string) bool {
+	return func(value string) bool {
+		value = strings.TrimSpace(value)
+		for _, v := range values {
+			if strings.EqualFold(value, v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (s CellStyle) matches(value string) bool {
+	if s.When == nil {
+		return strings.TrimSpace(value) != ""
+	}
+	return s.When(value)
+}
+
+// styleCell returns the inline content of a cell holding value, written as
+// formatted, with the matching styles applied.
+func styleCell(value, formatted string, raw bool, styles []CellStyle) []ast.Node {
+	content := cellNode(formatted, raw)
+	var prefix string
+	bold := false
+	for _, style := range styles {
+		if !style.matches(value) {
+			continue
+		}
+		if style.BadgeColor != "" {
+			content = badgeNode(formatted, style.BadgeColor)
+		}
+		prefix += style.Prefix
+		bold = bold || style.Bold
+	}
+	nodes := []ast.Node{content}
+	if prefix != "" {
+		nodes = []ast.Node{textNode(prefix), content}
+	}
+	if !bold {
+		return nodes
+	}
+	strong := ast.NewEmphasis(2)
+	for _, node := range nodes {
+		strong.AppendChild(strong, node)
+	}
+	return []ast.Node{strong}
+}
+
+// badgeNode returns a shields.io static badge image showing text.
+func badgeNode(text, color string) ast.Node {
+	escape := strings.NewReplacer("-", "--", "_", "__", " ", "_")
+	link := ast.NewLink()
+	link.Destination = []byte(escapeLinkDestination("https://img.shields.io/badge/" +
+		url.PathEscape(escape.Replace(text)) + "-" + url.PathEscape(color)))
+	image := ast.NewImage(link)
+	image.AppendChild(image, textNode(text))
+	return image
+}
@@ -0,0 +1,79 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCellFormatters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		formatter CellFormatter
+		value     string
+		want      string
+	}{
+		{name: "number", formatter: FormatNumber(2), value: "3.14159", want: "3.14"},
+		{name: "number keeps text", formatter: FormatNumber(2), value: "n/a", want: "n/a"},
+		{name: "thousands", formatter: FormatThousands(0), value: "1234567", want: "1,234,567"},
+		{name: "thousands decimals", formatter: FormatThousands(1), value: "-9876.54", want: "-9,876.5"},
+		{name: "percent fraction", formatter: FormatPercent(1), value: "0.125", want: "12.5%"},
+		{name: "percent value", formatter: FormatPercent(0), value: "42.4%", want: "42%"},
+		{name: "duration", formatter: FormatDuration(time.Millisecond), value: "1500", want: "1.5s"},
+		{name: "duration string", formatter: FormatDuration(time.Second), value: "90m", want: "1h30m0s"},
+		{name: "bytes", formatter: FormatBytes(), value: "512", want: "512 B"},
+		{name: "kibibytes", formatter: FormatBytes(), value: "1536", want: "1.5 KiB"},
+		{name: "gibibytes", formatter: FormatBytes(), value: "3221225472", want: "3 GiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.formatter(tt.value); got != tt.want {
+				t.Fatalf("unexpected value\nwant: %q\ngot:  %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMarkdownCustomTableStyles(t *testing.T) {
+	t.Parallel()
+
+	set := TableSet{
+		Header:    []string{"service", "p99", "status"},
+		Rows:      [][]string{{"api", "1500", "failing"}, {"web", "250", "passing"}},
+		Footer:    []string{"Total", "1750", ""},
+		Alignment: []TableAlignment{AlignLeft, AlignRight, AlignLeft},
+	}
+	var buf bytes.Buffer
+	err := NewMarkdown(&buf, WithLineEnding("\n"), WithTablePadding(false)).
+		CustomTable(set, TableOptions{
+			AutoFormatHeaders: true,
+			Formatters:        map[string]CellFormatter{"p99": FormatDuration(time.Millisecond)},
+			Styles: map[string][]CellStyle{
+				"p99": {{When: ValueAbove(1000), Bold: true, Prefix: "🔥 "}},
+				"status": {
+					{When: ValueEquals("failing"), BadgeColor: "red"},
+					{When: ValueEquals("passing"), BadgeColor: "brightgreen"},
+				},
+			},
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"| Service | P99 | Status |",
+		"| :-- | --: | :-- |",
+		"| api | **🔥 1.5s** | ![failing](https://img.shields.io/badge/failing-red) |",
+		"| web | 250ms | ![passing](https://img.shields.io/badge/passing-brightgreen) |",
+		"| **Total** | **1.75s** |  |",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected table\nwant: %q\ngot:  %q", want, got)
+	}
+}
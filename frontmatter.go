@@ -0,0 +1,193 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// FrontMatterFormat is the syntax of the front matter of a document.
+type FrontMatterFormat int
+
+const (
+	// YAMLFrontMatter is front matter between --- lines.
+	YAMLFrontMatter FrontMatterFormat = iota
+	// TOMLFrontMatter is front matter between +++ lines, as Hugo accepts.
+	TOMLFrontMatter
+)
+
+func (f FrontMatterFormat) delimiter() string {
+	if f == TOMLFrontMatter {
+		return "+++"
+	}
+	return "---"
+}
+
+// FrontMatter sets the front matter of the document to values written as
+// YAML, with keys in sorted order. Front matter is written first whenever it
+// is set, and replaces any front matter set before or read by Parse.
+func (m *Markdown) FrontMatter(values map[string]any) *Markdown {
+	return m.FrontMatterYAML(values)
+}
+
+// FrontMatterYAML sets the front matter of the document to v written as
+// YAML by gopkg.in/yaml.v3. v is a map, whose keys are sorted, or a struct,
+// whose fields are named by their yaml tags.
+func (m *Markdown) FrontMatterYAML(v any) *Markdown {
+	return m.setFrontMatter(YAMLFrontMatter, v)
+}
+
+// FrontMatterTOML sets the front matter of the document to v written as
+// TOML by github.com/BurntSushi/toml. v is a map or a struct, whose fields
+// are named by their toml tags.
+func (m *Markdown) FrontMatterTOML(v any) *Markdown {
+	return m.setFrontMatter(TOMLFrontMatter, v)
+}
+
+func (m *Markdown) setFrontMatter(format FrontMatterFormat, v any) *Markdown {
+	text, err := encodeFrontMatter(format, v)
+	if err != nil {
+		if m.err != nil {
+			m.err = fmt.Errorf("failed to encode front matter: %w: %s", err, m.err)
+		} else {
+			m.err = fmt.Errorf("failed to encode front matter: %w", err)
+		}
+		return m
+	}
+	m.putFrontMatter(newFrontMatterBlock(format, text))
+	return m
+}
+
+func encodeFrontMatter(format FrontMatterFormat, v any) (text string, err error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Map && value.Kind() != reflect.Struct {
+		return "", fmt.Errorf("%w: %T is not a map or struct", ErrInvalidFrontMatter, v)
+	}
+
+	var buf bytes.Buffer
+	if format == TOMLFrontMatter {
+		enc := toml.NewEncoder(&buf)
+		enc.Indent = ""
+		err = enc.Encode(v)
+	} else {
+		// yaml.v3 panics on types it can't marshal, such as functions.
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%w: %v", ErrInvalidFrontMatter, r)
+			}
+		}()
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err = enc.Encode(v); err == nil {
+			err = enc.Close()
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidFrontMatter, err)
+	}
+	text = strings.TrimRight(buf.String(), "\n")
+	if text == "{}" {
+		text = ""
+	}
+	return text, nil
+}
+
+// putFrontMatter makes block the first child of the document, replacing
+// existing front matter.
+func (m *Markdown) putFrontMatter(block *frontMatterBlock) {
+	if existing, ok := m.doc.FirstChild().(*frontMatterBlock); ok {
+		m.doc.ReplaceChild(m.doc, existing, block)
+		return
+	}
+	if first := m.doc.FirstChild(); first != nil {
+		m.doc.InsertBefore(m.doc, first, block)
+		return
+	}
+	m.doc.AppendChild(m.doc, block)
+}
+
+// FrontMatterValues returns the front matter of the document decoded by
+// gopkg.in/yaml.v3 or github.com/BurntSushi/toml. It returns nil when the
+// document has no front matter.
+func (m *Markdown) FrontMatterValues() (map[string]any, error) {
+	block, ok := m.doc.FirstChild().(*frontMatterBlock)
+	if !ok {
+		return nil, nil
+	}
+	return decodeFrontMatter(block.format, block.text)
+}
+
+func decodeFrontMatter(format FrontMatterFormat, text string) (map[string]any, error) {
+	values := map[string]any{}
+	var err error
+	if format == TOMLFrontMatter {
+		_, err = toml.Decode(text, &values)
+	} else {
+		err = yaml.Unmarshal([]byte(text), &values)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFrontMatter, err)
+	}
+	return values, nil
+}
+
+// splitFrontMatter separates front matter from the start of src. The
+// front matter is kept as written, so that parsed documents render it
+// unchanged. A document opening with a --- thematic break isn't front
+// matter: the block must start on the line after the delimiter and decode.
+func splitFrontMatter(src []byte) (*frontMatterBlock, []byte) {
+	for _, format := range []FrontMatterFormat{YAMLFrontMatter, TOMLFrontMatter} {
+		delimiter := format.delimiter()
+		first, rest, ok := cutLine(src)
+		if !ok || strings.TrimRight(string(first), " \t") != delimiter {
+			continue
+		}
+		if next, _, ok := cutLine(rest); !ok || strings.TrimSpace(string(next)) == "" {
+			continue
+		}
+		var lines []string
+		for len(rest) > 0 {
+			var line []byte
+			line, rest, _ = cutLine(rest)
+			closing := strings.TrimRight(string(line), " \t")
+			if closing == delimiter || (format == YAMLFrontMatter && closing == "...") {
+				text := strings.Join(lines, "\n")
+				if _, err := decodeFrontMatter(format, text); err != nil {
+					break
+				}
+				return newFrontMatterBlock(format, text), rest
+			}
+			lines = append(lines, string(line))
+		}
+	}
+	return nil, src
+}
+
+// cutLine returns the first line of src without its line ending, and the
+// rest of src.
+func cutLine(src []byte) ([]byte, []byte, bool) {
+	if len(src) == 0 {
+		return nil, nil, false
+	}
+	line, rest, found := bytes.Cut(src, []byte("\n"))
+	if !found {
+		rest = nil
+	}
+	return bytes.TrimSuffix(line, []byte("\r")), rest, true
+}
+
+func (r *markdownRenderer) renderFrontMatterLines(n *frontMatterBlock) []string {
+	delimiter := n.format.delimiter()
+	lines := []string{delimiter}
+	if n.text != "" {
+		lines = append(lines, splitLines(n.text)...)
+	}
+	return append(lines, delimiter)
+}
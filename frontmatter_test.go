@@ -0,0 +1,182 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarkdownFrontMatter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := NewMarkdown(&buf, WithLineEnding("\n")).
+		H1("Release notes").
+		FrontMatter(map[string]any{"title": "Release: v2", "weight": 10, "draft": false, "tags": []string{"go", "docs"}, "build": "0x1F"}).
+		PlainText("Body.").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := strings.Join([]string{
+		"---",
+		`build: "0x1F"`,
+		"draft: false",
+		"tags:",
+		"  - go",
+		"  - docs",
+		"title: 'Release: v2'",
+		"weight: 10",
+		"---",
+		"",
+		"# Release notes",
+		"",
+		"Body.",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected document\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+type pageMeta struct {
+	Title   string    `yaml:"title" toml:"title"`
+	Date    time.Time `yaml:"date" toml:"date"`
+	Authors []string  `yaml:"authors,omitempty" toml:"authors,omitempty"`
+	Draft   bool      `yaml:"draft" toml:"draft"`
+	Params  struct {
+		Math bool `yaml:"math" toml:"math"`
+	} `yaml:"params" toml:"params"`
+	Internal string `yaml:"-" toml:"-"`
+}
+
+func TestMarkdownFrontMatterStruct(t *testing.T) {
+	t.Parallel()
+
+	meta := pageMeta{Title: "Intro", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Internal: "x"}
+	meta.Params.Math = true
+
+	got := NewMarkdown(nil, WithLineEnding("\n")).FrontMatterYAML(meta).String()
+	want := "---\ntitle: Intro\ndate: 2024-03-01T00:00:00Z\ndraft: false\nparams:\n  math: true\n---\n"
+	if got != want {
+		t.Fatalf("unexpected yaml\nwant: %q\ngot:  %q", want, got)
+	}
+
+	got = NewMarkdown(nil, WithLineEnding("\n")).FrontMatterTOML(&meta).String()
+	want = "+++\ntitle = \"Intro\"\ndate = 2024-03-01T00:00:00Z\ndraft = false\n\n[params]\nmath = true\n+++\n"
+	if got != want {
+		t.Fatalf("unexpected toml\nwant: %q\ngot:  %q", want, got)
+	}
+
+	err := NewMarkdown(nil).FrontMatterYAML([]string{"a"}).Error()
+	if !errors.Is(err, ErrInvalidFrontMatter) {
+		t.Fatalf("expected ErrInvalidFrontMatter, got %v", err)
+	}
+	err = NewMarkdown(nil).FrontMatter(map[string]any{"f": func() {}}).Error()
+	if !errors.Is(err, ErrInvalidFrontMatter) {
+		t.Fatalf("expected ErrInvalidFrontMatter, got %v", err)
+	}
+}
+
+func TestParseFrontMatter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want map[string]any
+		out  string
+	}{
+		{
+			name: "yaml",
+			src:  "---\ntitle: Hello # comment\ncount: 3\n---\n# Hello\n",
+			want: map[string]any{"title": "Hello", "count": 3},
+			out:  "---\ntitle: Hello # comment\ncount: 3\n---\n\n# Hello\n",
+		},
+		{
+			name: "toml",
+			src:  "+++\ntitle = 'Hello'\n[params]\nmath = true\n+++\n# Hello\n",
+			want: map[string]any{"title": "Hello", "params": map[string]any{"math": true}},
+			out:  "+++\ntitle = 'Hello'\n[params]\nmath = true\n+++\n\n# Hello\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := Parse(nil, []byte(tt.src), WithLineEnding("\n"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			values, err := m.FrontMatterValues()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(values, tt.want) {
+				t.Fatalf("unexpected values\nwant: %#v\ngot:  %#v", tt.want, values)
+			}
+			if got := m.String(); got != tt.out {
+				t.Fatalf("unexpected round trip\nwant: %q\ngot:  %q", tt.out, got)
+			}
+			if len(m.headers) != 1 || m.headers[0].text != "Hello" {
+				t.Fatalf("unexpected headers: %+v", m.headers)
+			}
+		})
+	}
+
+	m, err := Parse(nil, []byte("---\nold: true\n---\ntext\n"), WithLineEnding("\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := m.FrontMatter(map[string]any{"new": 1}).String(), "---\nnew: 1\n---\n\ntext\n"; got != want {
+		t.Fatalf("unexpected document\nwant: %q\ngot:  %q", want, got)
+	}
+
+	m, err = Parse(nil, []byte("---\n\nNot front matter.\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if values, err := m.FrontMatterValues(); values != nil || err != nil {
+		t.Fatalf("expected no front matter, got %v, %v", values, err)
+	}
+}
+
+func TestParseThematicBreakIsNotFrontMatter(t *testing.T) {
+	t.Parallel()
+
+	for _, src := range []string{
+		"---\n\n| A |\n| - |\n| 1 |\n\n---\n\nMore\n",
+		"---\nJust a setext heading\n---\n",
+		"+++\nnot = toml = at all\n+++\n",
+	} {
+		m, err := Parse(nil, []byte(src), WithLineEnding("\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if values, err := m.FrontMatterValues(); values != nil || err != nil {
+			t.Fatalf("expected no front matter in %q, got %v, %v", src, values, err)
+		}
+	}
+
+	src := "---\n\n| A |\n| - |\n| 1 |\n\n---\n\nMore\n"
+	sets, err := ParseTables(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sets) != 1 || sets[0].Rows[0][0] != "1" {
+		t.Fatalf("expected the table, got %#v", sets)
+	}
+	m, err := Parse(nil, []byte(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	html, err := m.HTML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(html, "<hr>") || !strings.Contains(html, "<td>1</td>") {
+		t.Fatalf("unexpected html: %q", html)
+	}
+}
@@ -2,4 +2,8 @@ module github.com/ivanvanderbyl/markdown
 
 go 1.25.1
 
-require github.com/yuin/goldmark v1.7.13
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/yuin/goldmark v1.7.13
+	gopkg.in/yaml.v3 v3.0.1
+)
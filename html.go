@@ -0,0 +1,235 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+func newHTMLRenderer(opts options) renderer.Renderer {
+	return goldmark.New(
+		goldmark.WithExtensions(gfmExtensions...),
+		goldmark.WithRendererOptions(
+			html.WithUnsafe(),
+			renderer.WithNodeRenderers(util.Prioritized(&htmlNodeRenderer{options: opts}, 100)),
+		),
+	).Renderer()
+}
+
+// HTML returns the document rendered as HTML.
+func (m *Markdown) HTML() (string, error) {
+	m.resolveTableOfContents()
+	m.assignHeadingIDs()
+	var buf bytes.Buffer
+	if err := newHTMLRenderer(m.options).Render(&buf, m.source, m.doc); err != nil {
+		return "", fmt.Errorf("failed to render html: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// BuildHTML writes the document rendered as HTML to output destination.
+func (m *Markdown) BuildHTML() error {
+	out, err := m.HTML()
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(m.dest, out); err != nil {
+		if m.err != nil {
+			return fmt.Errorf("failed to write html: %w: %s", err, m.err.Error())
+		}
+		return fmt.Errorf("failed to write html: %w", err)
+	}
+	return m.err
+}
+
+func (m *Markdown) assignHeadingIDs() {
+	r := m.newRenderer()
+	slugger := m.options.slugger()
+	_ = ast.Walk(m.doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if h, ok := node.(*ast.Heading); ok && entering {
+			h.SetAttributeString("id", []byte(slugger.Slug(r.collectPlainText(h))))
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// htmlNodeRenderer renders the node kinds defined by this package, plus
+// ast.String values. Raw strings and literal blocks hold trusted Markdown
+// and are converted with gfmMarkdown; other strings are plain text unless
+// escaping is disabled.
+type htmlNodeRenderer struct {
+	options
+}
+
+func (r *htmlNodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindLiteralBlock, r.renderLiteralBlock)
+	reg.Register(kindCodeBlock, r.renderCodeBlock)
+	reg.Register(kindCallout, r.renderCallout)
+	reg.Register(kindHighlight, r.renderHighlight)
+	reg.Register(kindTOC, r.renderTOC)
+	reg.Register(kindDetails, r.renderDetails)
+	reg.Register(kindLineBreak, r.renderLineBreak)
+	reg.Register(kindFrontMatter, r.renderFrontMatter)
+	reg.Register(ast.KindString, r.renderString)
+	reg.Register(ast.KindCodeSpan, r.renderCodeSpan)
+}
+
+// renderTOC writes nothing itself; the title and lists it holds are
+// rendered as regular children.
+func (r *htmlNodeRenderer) renderTOC(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	return ast.WalkContinue, nil
+}
+
+func (r *htmlNodeRenderer) renderDetails(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		_, _ = w.WriteString("</details>\n")
+		return ast.WalkContinue, nil
+	}
+	n := node.(*detailsBlock)
+	_, _ = w.WriteString("<details><summary>")
+	if err := renderInlineFragment(w, []byte(n.summary)); err != nil {
+		return ast.WalkStop, err
+	}
+	_, _ = w.WriteString("</summary>\n")
+	return ast.WalkContinue, nil
+}
+
+// renderFrontMatter writes nothing; front matter is metadata for site
+// generators rather than content.
+func (r *htmlNodeRenderer) renderFrontMatter(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *htmlNodeRenderer) renderLineBreak(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<br>\n")
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *htmlNodeRenderer) renderLiteralBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*literalBlock)
+	if err := gfmMarkdown.Convert([]byte(n.value+"\n"), w); err != nil {
+		return ast.WalkStop, err
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *htmlNodeRenderer) renderCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*codeBlockNode)
+	_, _ = w.WriteString("<pre><code")
+	if n.language != "" {
+		_, _ = w.WriteString(` class="language-`)
+		html.DefaultWriter.RawWrite(w, []byte(n.language))
+		_ = w.WriteByte('"')
+	}
+	_ = w.WriteByte('>')
+	html.DefaultWriter.RawWrite(w, []byte(n.value))
+	if n.value != "" && !strings.HasSuffix(n.value, "\n") {
+		_ = w.WriteByte('\n')
+	}
+	_, _ = w.WriteString("</code></pre>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *htmlNodeRenderer) renderCallout(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		_, _ = w.WriteString("</div>\n")
+		return ast.WalkContinue, nil
+	}
+	n := node.(*calloutBlock)
+	_, _ = fmt.Fprintf(w, "<div class=\"markdown-alert markdown-alert-%s\">\n", strings.ToLower(n.label))
+	_, _ = fmt.Fprintf(w, "<p class=\"markdown-alert-title\">%s</p>\n", calloutTitle(n.label))
+	return ast.WalkContinue, nil
+}
+
+func (r *htmlNodeRenderer) renderHighlight(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<mark>")
+	} else {
+		_, _ = w.WriteString("</mark>")
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderCodeSpan mirrors goldmark's code span rendering but also accepts the
+// ast.String children created by Inline.Code.
+func (r *htmlNodeRenderer) renderCodeSpan(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		_, _ = w.WriteString("</code>")
+		return ast.WalkContinue, nil
+	}
+	_, _ = w.WriteString("<code")
+	if node.Attributes() != nil {
+		html.RenderAttributes(w, node, html.CodeAttributeFilter)
+	}
+	_ = w.WriteByte('>')
+	for c := node.FirstChild(); c != nil; c = c.NextSibling() {
+		switch t := c.(type) {
+		case *ast.Text:
+			value := t.Segment.Value(source)
+			if bytes.HasSuffix(value, []byte("\n")) {
+				value = append(value[:len(value)-1:len(value)-1], ' ')
+			}
+			html.DefaultWriter.RawWrite(w, value)
+		case *ast.String:
+			html.DefaultWriter.RawWrite(w, t.Value)
+		}
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *htmlNodeRenderer) renderString(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.String)
+	if n.IsCode() {
+		_, _ = w.Write(n.Value)
+		return ast.WalkContinue, nil
+	}
+	if !n.IsRaw() && !r.rawText {
+		html.DefaultWriter.RawWrite(w, n.Value)
+		return ast.WalkContinue, nil
+	}
+	if err := renderInlineFragment(w, n.Value); err != nil {
+		return ast.WalkStop, err
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderInlineFragment renders a Markdown fragment without wrapping it in a
+// paragraph, so it can be embedded in headings, cells and list items.
+// Surrounding whitespace, which the parser would trim, is kept.
+func renderInlineFragment(w util.BufWriter, value []byte) error {
+	trimmed := bytes.TrimLeft(value, " \t")
+	core := bytes.TrimRight(trimmed, " \t")
+	_, _ = w.Write(value[:len(value)-len(trimmed)])
+	defer func() { _, _ = w.Write(trimmed[len(core):]) }()
+
+	doc := gfmMarkdown.Parser().Parse(text.NewReader(core))
+	para, ok := doc.FirstChild().(*ast.Paragraph)
+	if !ok || para.NextSibling() != nil {
+		return gfmMarkdown.Renderer().Render(w, core, doc)
+	}
+	for child := para.FirstChild(); child != nil; child = child.NextSibling() {
+		if err := gfmMarkdown.Renderer().Render(w, core, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package markdown
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestMarkdownHTML(t *testing.T) {
+	t.Parallel()
+
+	md := NewMarkdown(io.Discard)
+	md.H1("Report").
+		H2("Summary").
+		TableOfContents(TableOfContentsDepthH2).
+		Raw(Bold("Status")+": green").
+		Table(TableSet{
+			Header:    []string{"Name", "Count"},
+			Rows:      [][]string{{"alpha", "2"}},
+			Alignment: []TableAlignment{AlignLeft, AlignRight},
+		}).
+		Note("Generated file").
+		CodeBlocks(SyntaxHighlightGo, "x := 1 < 2")
+
+	got, err := md.HTML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `<h1 id="report">Report</h1>
+<h2 id="summary">Summary</h2>
+<ul>
+<li><a href="#report">Report</a>
+<ul>
+<li><a href="#summary">Summary</a></li>
+</ul>
+</li>
+</ul>
+<p><strong>Status</strong>: green</p>
+<table>
+<thead>
+<tr>
+<th style="text-align:left">Name</th>
+<th style="text-align:right">Count</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td style="text-align:left">alpha</td>
+<td style="text-align:right">2</td>
+</tr>
+</tbody>
+</table>
+<div class="markdown-alert markdown-alert-note">
+<p class="markdown-alert-title">Note</p>
+<p>Generated file</p>
+</div>
+<pre><code class="language-go">x := 1 &lt; 2
+</code></pre>
+`
+	if got != want {
+		t.Fatalf("unexpected html output\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+func TestMarkdownBuildHTML(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	md := NewMarkdown(&buf)
+	md.BulletList("one", "two").
+		CheckBox([]CheckBoxSet{{Text: "done", Checked: true}})
+
+	if err := md.BuildHTML(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n" +
+		"<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> done</li>\n</ul>\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected html output\nwant: %q\ngot:  %q", want, got)
+	}
+}
@@ -0,0 +1,295 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// HTMLTable describes a table written as HTML, for layouts pipe tables can't
+// express: cells spanning several columns or rows, several header rows, a
+// caption and per-cell alignment.
+type HTMLTable struct {
+	Caption string
+	// Header, Rows and Footer are the rows of the thead, tbody and tfoot
+	// sections. Cells covered by a span of an earlier cell are left out.
+	Header [][]HTMLCell
+	Rows   [][]HTMLCell
+	Footer [][]HTMLCell
+	// Alignment is the default alignment of each column.
+	Alignment []TableAlignment
+}
+
+// HTMLCell is a cell of an HTMLTable.
+type HTMLCell struct {
+	// Text is the content of the cell. It is escaped unless the builder was
+	// created with WithoutEscaping, and line breaks are written as <br>.
+	Text string
+	// HTML is trusted HTML written instead of Text when it is set, such as a
+	// list or a code block.
+	HTML string
+	// ColSpan and RowSpan are the number of columns and rows the cell
+	// covers. Values below 2 cover one.
+	ColSpan int
+	RowSpan int
+	// Align overrides the alignment of the column.
+	Align TableAlignment
+}
+
+// ColumnGroup is a header cell spanning several columns of a TableSet.
+type ColumnGroup struct {
+	Title   string
+	Columns int
+}
+
+// CellSpan merges the body cells of a TableSet from Row and Column over
+// Rows rows and Columns columns. The text of the first cell is kept.
+type CellSpan struct {
+	Row, Column   int
+	Rows, Columns int
+}
+
+// HTMLTable appends a table written as an HTML block.
+func (m *Markdown) HTMLTable(table HTMLTable) *Markdown {
+	m.appendBlock(newLiteralBlock(table.markup(m.options.rawText)))
+	return m
+}
+
+func (t HTMLTable) markup(raw bool) string {
+	lines := []string{"<table>"}
+	if t.Caption != "" {
+		lines = append(lines, "  <caption>"+htmlText(t.Caption, raw)+"</caption>")
+	}
+	sections := []struct {
+		tag, cell string
+		rows      [][]HTMLCell
+	}{
+		{"thead", "th", t.Header},
+		{"tbody", "td", t.Rows},
+		{"tfoot", "td", t.Footer},
+	}
+	for _, section := range sections {
+		if len(section.rows) == 0 {
+			continue
+		}
+		lines = append(lines, "  <"+section.tag+">")
+		// spans counts the rows each column is still covered for by a
+		// row span, so that cells take the alignment of their column.
+		var spans []int
+		for _, row := range section.rows {
+			lines = append(lines, "    <tr>")
+			column := 0
+			for _, cell := range row {
+				for column < len(spans) && spans[column] > 0 {
+					column++
+				}
+				lines = append(lines, "      "+t.cellMarkup(section.cell, cell, column, raw))
+				width := max(cell.ColSpan, 1)
+				for len(spans) < column+width {
+					spans = append(spans, 0)
+				}
+				for i := column; i < column+width; i++ {
+					spans[i] = max(cell.RowSpan, 1)
+				}
+				column += width
+			}
+			for i := range spans {
+				if spans[i] > 0 {
+					spans[i]--
+				}
+			}
+			lines = append(lines, "    </tr>")
+		}
+		lines = append(lines, "  </"+section.tag+">")
+	}
+	lines = append(lines, "</table>")
+	return strings.Join(lines, "\n")
+}
+
+func (t HTMLTable) cellMarkup(tag string, cell HTMLCell, column int, raw bool) string {
+	var buf strings.Builder
+	buf.WriteString("<" + tag)
+	if cell.ColSpan > 1 {
+		fmt.Fprintf(&buf, ` colspan="%d"`, cell.ColSpan)
+	}
+	if cell.RowSpan > 1 {
+		fmt.Fprintf(&buf, ` rowspan="%d"`, cell.RowSpan)
+	}
+	align := cell.Align
+	if align == AlignDefault && column < len(t.Alignment) {
+		align = t.Alignment[column]
+	}
+	switch align {
+	case AlignLeft:
+		buf.WriteString(` align="left"`)
+	case AlignCenter:
+		buf.WriteString(` align="center"`)
+	case AlignRight:
+		buf.WriteString(` align="right"`)
+	}
+	buf.WriteString(">")
+	if cell.HTML != "" {
+		buf.WriteString(cell.HTML)
+	} else {
+		buf.WriteString(htmlText(cell.Text, raw))
+	}
+	buf.WriteString("</" + tag + ">")
+	return buf.String()
+}
+
+// htmlText escapes text for an HTML block. Line breaks are written as <br>,
+// as a blank line would end the block.
+func htmlText(text string, raw bool) string {
+	if !raw {
+		text = html.EscapeString(text)
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+// needsHTML reports whether the table uses layout pipe tables can't express.
+func (t *TableSet) needsHTML() bool {
+	return t.Caption != "" || len(t.HeaderGroups) > 0 || len(t.Spans) > 0
+}
+
+// htmlTable converts the table to an HTMLTable. Column groups with an empty
+// title leave their header cells spanning both header rows. When cellHTML is
+// set it supplies the markup of the header, body and footer cells; header
+// cells are passed row -1 and footer cells row len(t.Rows).
+func (t *TableSet) htmlTable(cellHTML func(row, column int, text string) string) (HTMLTable, error) {
+	if err := t.ValidateColumns(); err != nil {
+		return HTMLTable{}, err
+	}
+	table := HTMLTable{Caption: t.Caption, Alignment: t.Alignment}
+	cell := func(row, column int, text string) HTMLCell {
+		c := HTMLCell{Text: text}
+		if cellHTML != nil {
+			c.HTML = cellHTML(row, column, text)
+		}
+		return c
+	}
+
+	header := make([]HTMLCell, 0, len(t.Header))
+	if len(t.HeaderGroups) > 0 {
+		var groups []HTMLCell
+		column := 0
+		for _, group := range t.HeaderGroups {
+			width := max(group.Columns, 1)
+			if column+width > len(t.Header) {
+				return HTMLTable{}, fmt.Errorf("%w: column group %q ends after column %d", ErrInvalidSpan, group.Title, len(t.Header))
+			}
+			if group.Title == "" {
+				for i, text := range t.Header[column : column+width] {
+					c := cell(-1, column+i, text)
+					c.RowSpan = 2
+					groups = append(groups, c)
+				}
+			} else {
+				groups = append(groups, HTMLCell{Text: group.Title, ColSpan: width, Align: AlignCenter})
+				for i, text := range t.Header[column : column+width] {
+					header = append(header, cell(-1, column+i, text))
+				}
+			}
+			column += width
+		}
+		if column != len(t.Header) {
+			return HTMLTable{}, fmt.Errorf("%w: column groups cover %d of %d columns", ErrInvalidSpan, column, len(t.Header))
+		}
+		table.Header = append(table.Header, groups)
+	} else {
+		for i, text := range t.Header {
+			header = append(header, cell(-1, i, text))
+		}
+	}
+	if len(header) > 0 {
+		table.Header = append(table.Header, header)
+	}
+
+	covered := make([][]bool, len(t.Rows))
+	for i := range covered {
+		covered[i] = make([]bool, len(t.Header))
+	}
+	starts := map[[2]int]CellSpan{}
+	for _, span := range t.Spans {
+		rows, columns := max(span.Rows, 1), max(span.Columns, 1)
+		if span.Row < 0 || span.Column < 0 || span.Row+rows > len(t.Rows) || span.Column+columns > len(t.Header) {
+			return HTMLTable{}, fmt.Errorf("%w: span at row %d, column %d is outside the table", ErrInvalidSpan, span.Row+1, span.Column+1)
+		}
+		for r := span.Row; r < span.Row+rows; r++ {
+			for c := span.Column; c < span.Column+columns; c++ {
+				if covered[r][c] {
+					return HTMLTable{}, fmt.Errorf("%w: span at row %d, column %d overlaps another span", ErrInvalidSpan, span.Row+1, span.Column+1)
+				}
+				covered[r][c] = true
+			}
+		}
+		starts[[2]int{span.Row, span.Column}] = CellSpan{Rows: rows, Columns: columns}
+	}
+	for r, row := range t.Rows {
+		cells := []HTMLCell{}
+		for c, text := range row {
+			span, start := starts[[2]int{r, c}]
+			if covered[r][c] && !start {
+				continue
+			}
+			htmlCell := cell(r, c, text)
+			htmlCell.ColSpan, htmlCell.RowSpan = span.Columns, span.Rows
+			cells = append(cells, htmlCell)
+		}
+		table.Rows = append(table.Rows, cells)
+	}
+
+	if t.Footer != nil {
+		footer := make([]HTMLCell, len(t.Footer))
+		for i, text := range t.Footer {
+			footer[i] = cell(len(t.Rows), i, text)
+		}
+		table.Footer = [][]HTMLCell{footer}
+	}
+	return table, nil
+}
+
+// tableHTML appends set, which needs HTML layout, as an HTMLTable. Raw cells
+// and cells with inline content are converted to HTML from their nodes.
+func (m *Markdown) tableHTML(set TableSet, rawCells bool, content cellContent) *Markdown {
+	var cellHTML func(row, column int, text string) string
+	if rawCells || content != nil {
+		cellHTML = func(row, column int, text string) string {
+			if content != nil && row >= 0 && row < len(set.Rows) {
+				return m.inlineHTML(content(row, column, text)...)
+			}
+			return m.inlineHTML(cellNode(text, rawCells))
+		}
+	}
+	table, err := set.htmlTable(cellHTML)
+	if err != nil {
+		if m.err != nil {
+			m.err = fmt.Errorf("failed to validate columns: %w: %s", err, m.err)
+		} else {
+			m.err = fmt.Errorf("failed to validate columns: %w", err)
+		}
+		return m
+	}
+	return m.HTMLTable(table)
+}
+
+// inlineHTML renders inline nodes as HTML on one line, as an HTML block
+// ends at a blank line.
+func (m *Markdown) inlineHTML(nodes ...ast.Node) string {
+	para := ast.NewParagraph()
+	for _, node := range nodes {
+		para.AppendChild(para, node)
+	}
+	doc := ast.NewDocument()
+	doc.AppendChild(doc, para)
+	var buf bytes.Buffer
+	if err := newHTMLRenderer(m.options).Render(&buf, m.source, doc); err != nil {
+		return ""
+	}
+	out := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(buf.String()), "<p>"), "</p>")
+	out = strings.ReplaceAll(out, "<br>\n", "<br>")
+	return strings.ReplaceAll(out, "\n", " ")
+}
@@ -0,0 +1,160 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownHTMLTable(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := NewMarkdown(&buf, WithLineEnding("\n")).
+		HTMLTable(HTMLTable{
+			Caption:   "Q3 <draft>",
+			Alignment: []TableAlignment{AlignLeft, AlignRight},
+			Header:    [][]HTMLCell{{{Text: "Host"}, {Text: "Notes", Align: AlignCenter}}},
+			Rows: [][]HTMLCell{
+				{{Text: "a", RowSpan: 2}, {Text: "line 1\nline 2"}},
+				{{HTML: "<ul><li>x</li></ul>"}},
+			},
+			Footer: [][]HTMLCell{{{Text: "Total", ColSpan: 2}}},
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"<table>",
+		"  <caption>Q3 &lt;draft&gt;</caption>",
+		"  <thead>",
+		"    <tr>",
+		`      <th align="left">Host</th>`,
+		`      <th align="center">Notes</th>`,
+		"    </tr>",
+		"  </thead>",
+		"  <tbody>",
+		"    <tr>",
+		`      <td rowspan="2" align="left">a</td>`,
+		`      <td align="right">line 1<br>line 2</td>`,
+		"    </tr>",
+		"    <tr>",
+		`      <td align="right"><ul><li>x</li></ul></td>`,
+		"    </tr>",
+		"  </tbody>",
+		"  <tfoot>",
+		"    <tr>",
+		`      <td colspan="2" align="left">Total</td>`,
+		"    </tr>",
+		"  </tfoot>",
+		"</table>",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected table\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+func TestMarkdownTableHTMLFallback(t *testing.T) {
+	t.Parallel()
+
+	set := TableSet{
+		Header:       []string{"Region", "Used", "Free", "Used", "Free"},
+		HeaderGroups: []ColumnGroup{{Columns: 1}, {Title: "CPU", Columns: 2}, {Title: "Memory", Columns: 2}},
+		Rows: [][]string{
+			{"eu", "10", "6", "32", "32"},
+			{"us", "n/a", "", "64", "0"},
+		},
+		Spans: []CellSpan{{Row: 1, Column: 1, Columns: 2}},
+	}
+	var buf bytes.Buffer
+	md := NewMarkdown(&buf, WithLineEnding("\n")).Table(set)
+	if err := md.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	for _, fragment := range []string{
+		"<thead>\n    <tr>\n      <th rowspan=\"2\">Region</th>\n      <th colspan=\"2\" align=\"center\">CPU</th>",
+		"<tr>\n      <th>Used</th>\n      <th>Free</th>\n      <th>Used</th>",
+		"<tr>\n      <td>us</td>\n      <td colspan=\"2\">n/a</td>\n      <td>64</td>",
+	} {
+		if !strings.Contains(got, fragment) {
+			t.Fatalf("expected %q in table:\n%s", fragment, got)
+		}
+	}
+
+	html, err := md.HTML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(html, `<td colspan="2">n/a</td>`) {
+		t.Fatalf("expected the table to pass through as HTML:\n%s", html)
+	}
+
+	tests := []struct {
+		name string
+		set  TableSet
+	}{
+		{name: "groups too wide", set: TableSet{Header: []string{"A"}, HeaderGroups: []ColumnGroup{{Title: "G", Columns: 2}}}},
+		{name: "groups too narrow", set: TableSet{Header: []string{"A", "B"}, HeaderGroups: []ColumnGroup{{Title: "G"}}}},
+		{name: "span outside", set: TableSet{Header: []string{"A"}, Rows: [][]string{{"1"}}, Spans: []CellSpan{{Rows: 2}}}},
+		{name: "overlap", set: TableSet{
+			Header: []string{"A", "B"},
+			Rows:   [][]string{{"1", "2"}},
+			Spans:  []CellSpan{{Columns: 2}, {Column: 1}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := NewMarkdown(&bytes.Buffer{}).Table(tt.set).Build()
+			if !errors.Is(err, ErrInvalidSpan) {
+				t.Fatalf("expected ErrInvalidSpan, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMarkdownCustomTableHTMLFallback(t *testing.T) {
+	t.Parallel()
+
+	set := TableSet{
+		Caption: "Cap",
+		Header:  []string{"Service", "Errors"},
+		Rows:    [][]string{{"[api](https://api)", "12"}, {"db", "0"}},
+		Spans:   []CellSpan{{Row: 1, Columns: 2}},
+	}
+	got := NewMarkdown(nil, WithLineEnding("\n")).CustomTable(set, TableOptions{
+		RawCells:      true,
+		MaxTableWidth: 10,
+		Styles:        map[string][]CellStyle{"Errors": {{When: ValueAbove(10), Bold: true}}},
+	}).String()
+
+	want := strings.Join([]string{
+		"<table>",
+		"  <caption>Cap</caption>",
+		"  <thead>",
+		"    <tr>",
+		"      <th>Service</th>",
+		"      <th>Errors</th>",
+		"    </tr>",
+		"  </thead>",
+		"  <tbody>",
+		"    <tr>",
+		`      <td><a href="https://api">api</a></td>`,
+		"      <td><strong>12</strong></td>",
+		"    </tr>",
+		"    <tr>",
+		`      <td colspan="2">db</td>`,
+		"    </tr>",
+		"  </tbody>",
+		"</table>",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected table\nwant: %q\ngot:  %q", want, got)
+	}
+}
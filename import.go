@@ -0,0 +1,356 @@
+package markdown
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// HeaderMode tells table importers where the header comes from.
+type HeaderMode int
+
+const (
+	// HeaderDetect treats the first record as the header when it holds
+	// neither numbers nor empty values. This is the default.
+	HeaderDetect HeaderMode = iota
+	// HeaderFirstRow always treats the first record as the header.
+	HeaderFirstRow
+	// HeaderNone treats every record as data and names the columns
+	// "Column 1", "Column 2" and so on.
+	HeaderNone
+)
+
+// ImportOptions controls how TableFromCSV, TableFromTSV and TableFromJSON
+// build a TableSet.
+type ImportOptions struct {
+	// Comma is the field delimiter of TableFromCSV. Zero means ','.
+	Comma rune
+	// Header is where the header comes from. It doesn't apply to JSON
+	// objects, whose keys are the header.
+	Header HeaderMode
+	// Columns selects and orders the columns by header. Empty means every
+	// column in source order.
+	Columns []string
+	// NoAutoAlign keeps the default alignment for every column. By default
+	// columns holding only numbers are right-aligned.
+	NoAutoAlign bool
+}
+
+// cellKind is the type of an imported value, used for header detection and
+// alignment.
+type cellKind int
+
+const (
+	cellEmpty cellKind = iota
+	cellNumber
+	cellText
+)
+
+// importedTable holds the records of a source before they become a TableSet.
+type importedTable struct {
+	header  []string
+	records [][]string
+	kinds   [][]cellKind
+	// positions describes where each record starts in the source, such as
+	// "line 3", for error messages.
+	positions []string
+}
+
+// TableFromCSV reads comma-separated values into a TableSet. Records with a
+// different number of fields than the header are reported with their line.
+func TableFromCSV(r io.Reader, opts ImportOptions) (TableSet, error) {
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	return readDelimited(reader, opts)
+}
+
+// TableFromTSV reads tab-separated values into a TableSet. As in the IANA
+// format, every line is a record and quotes have no special meaning.
+func TableFromTSV(r io.Reader, opts ImportOptions) (TableSet, error) {
+	var table importedTable
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		table.add(strings.Split(text, "\t"), line)
+	}
+	if err := scanner.Err(); err != nil {
+		return TableSet{}, fmt.Errorf("failed to read table: %w", err)
+	}
+	return table.tableSet(opts)
+}
+
+func readDelimited(reader *csv.Reader, opts ImportOptions) (TableSet, error) {
+	// Field counts are checked by ValidateColumns so that the error names
+	// the offending line.
+	reader.FieldsPerRecord = -1
+	var table importedTable
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return TableSet{}, fmt.Errorf("failed to read table: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		table.add(record, line)
+	}
+	return table.tableSet(opts)
+}
+
+// add appends a record read as text from line.
+func (t *importedTable) add(record []string, line int) {
+	kinds := make([]cellKind, len(record))
+	for i, value := range record {
+		kinds[i] = textKind(value)
+	}
+	t.records = append(t.records, record)
+	t.kinds = append(t.kinds, kinds)
+	t.positions = append(t.positions, fmt.Sprintf("line %d", line))
+}
+
+// TableFromJSON reads a JSON array of objects or of arrays into a TableSet.
+// The header of an array of objects is the union of their keys in order of
+// appearance, and missing keys leave cells empty. Nested objects and arrays
+// are written as compact JSON.
+func TableFromJSON(r io.Reader, opts ImportOptions) (TableSet, error) {
+	var elements []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&elements); err != nil {
+		return TableSet{}, fmt.Errorf("failed to read table: %w", err)
+	}
+
+	var table importedTable
+	columns := map[string]int{}
+	for i, element := range elements {
+		position := fmt.Sprintf("element %d", i+1)
+		element = bytes.TrimSpace(element)
+		var values []json.RawMessage
+		switch {
+		case bytes.HasPrefix(element, []byte("{")):
+			if table.header == nil && len(table.records) > 0 {
+				return TableSet{}, fmt.Errorf("failed to read table: %s: %w", position, ErrInvalidJSONTable)
+			}
+			keys, objectValues, err := decodeObject(element)
+			if err != nil {
+				return TableSet{}, fmt.Errorf("failed to read table: %s: %w", position, err)
+			}
+			if table.header == nil {
+				table.header = []string{}
+			}
+			values = make([]json.RawMessage, len(table.header))
+			for j, key := range keys {
+				column, ok := columns[key]
+				if !ok {
+					column = len(table.header)
+					columns[key] = column
+					table.header = append(table.header, key)
+					values = append(values, nil)
+				}
+				values[column] = objectValues[j]
+			}
+		case bytes.HasPrefix(element, []byte("[")):
+			if table.header != nil {
+				return TableSet{}, fmt.Errorf("failed to read table: %s: %w", position, ErrInvalidJSONTable)
+			}
+			if err := json.Unmarshal(element, &values); err != nil {
+				return TableSet{}, fmt.Errorf("failed to read table: %s: %w", position, err)
+			}
+		default:
+			return TableSet{}, fmt.Errorf("failed to read table: %s: %w", position, ErrInvalidJSONTable)
+		}
+		record := make([]string, len(values))
+		kinds := make([]cellKind, len(values))
+		for j, value := range values {
+			record[j], kinds[j] = jsonCell(value)
+		}
+		table.records = append(table.records, record)
+		table.kinds = append(table.kinds, kinds)
+		table.positions = append(table.positions, position)
+	}
+	if table.header != nil {
+		// Objects seen before a key appeared lack its cells.
+		for i := range table.records {
+			for len(table.records[i]) < len(table.header) {
+				table.records[i] = append(table.records[i], "")
+				table.kinds[i] = append(table.kinds[i], cellEmpty)
+			}
+		}
+	}
+	return table.tableSet(opts)
+}
+
+// decodeObject returns the keys and values of a JSON object in source order.
+func decodeObject(data []byte) ([]string, []json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+	var keys []string
+	var values []json.RawMessage
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values, nil
+}
+
+func jsonCell(value json.RawMessage) (string, cellKind) {
+	value = bytes.TrimSpace(value)
+	if len(value) == 0 || bytes.Equal(value, []byte("null")) {
+		return "", cellEmpty
+	}
+	switch value[0] {
+	case '"':
+		var s string
+		_ = json.Unmarshal(value, &s)
+		if s == "" {
+			return "", cellEmpty
+		}
+		return s, cellText
+	case '{', '[':
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, value); err != nil {
+			return string(value), cellText
+		}
+		return buf.String(), cellText
+	case 't', 'f':
+		return string(value), cellText
+	default:
+		return string(value), cellNumber
+	}
+}
+
+var numberPattern = regexp.MustCompile(`^[-+]?(\d{1,3}(,\d{3})+|\d*)(\.\d+)?([eE][-+]?\d+)?%?$`)
+
+// textKind infers the kind of a value read as text. Numbers may use
+// thousands separators and a percent sign.
+func textKind(value string) cellKind {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return cellEmpty
+	case strings.ContainsAny(value, "0123456789") && numberPattern.MatchString(value):
+		return cellNumber
+	default:
+		return cellText
+	}
+}
+
+// tableSet validates the records, splits off the header and applies column
+// selection and alignment.
+func (t importedTable) tableSet(opts ImportOptions) (TableSet, error) {
+	records, kinds, positions := t.records, t.kinds, t.positions
+	header := t.header
+	if header == nil {
+		firstIsHeader := false
+		switch opts.Header {
+		case HeaderFirstRow:
+			firstIsHeader = len(records) > 0
+		case HeaderDetect:
+			firstIsHeader = looksLikeHeader(kinds)
+		}
+		if firstIsHeader {
+			header = records[0]
+			records, kinds, positions = records[1:], kinds[1:], positions[1:]
+		} else if len(records) > 0 {
+			header = make([]string, len(records[0]))
+			for i := range header {
+				header[i] = fmt.Sprintf("Column %d", i+1)
+			}
+		}
+	}
+
+	set := TableSet{Header: header, Rows: records}
+	if err := set.ValidateColumns(); err != nil {
+		var mismatch *ColumnMismatchError
+		if errors.As(err, &mismatch) {
+			return TableSet{}, fmt.Errorf("%s: %w", positions[mismatch.Row], err)
+		}
+		return TableSet{}, err
+	}
+
+	order := make([]int, len(header))
+	for i := range order {
+		order[i] = i
+	}
+	if len(opts.Columns) > 0 {
+		order = order[:0]
+		for _, name := range opts.Columns {
+			index := -1
+			for i, h := range header {
+				if h == name {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				return TableSet{}, fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+			}
+			order = append(order, index)
+		}
+	}
+
+	result := TableSet{
+		Header:    make([]string, len(order)),
+		Rows:      make([][]string, len(records)),
+		Alignment: make([]TableAlignment, len(order)),
+	}
+	for i, column := range order {
+		result.Header[i] = header[column]
+		numeric := false
+		for _, row := range kinds {
+			if row[column] == cellText {
+				numeric = false
+				break
+			}
+			if row[column] == cellNumber {
+				numeric = true
+			}
+		}
+		if numeric && !opts.NoAutoAlign {
+			result.Alignment[i] = AlignRight
+		}
+	}
+	for i, record := range records {
+		row := make([]string, len(order))
+		for j, column := range order {
+			row[j] = record[column]
+		}
+		result.Rows[i] = row
+	}
+	return result, nil
+}
+
+// looksLikeHeader reports whether the first record is a header, which holds
+// neither numbers nor empty values.
+func looksLikeHeader(kinds [][]cellKind) bool {
+	if len(kinds) == 0 || len(kinds[0]) == 0 {
+		return false
+	}
+	for _, kind := range kinds[0] {
+		if kind != cellText {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package markdown
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableFromCSV(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		opts  ImportOptions
+		want  TableSet
+	}{
+		{
+			name:  "detected header and numeric alignment",
+			input: "name,visits,share\nhome,\"1,204\",12.5%\nabout,87,\n",
+			want: TableSet{
+				Header:    []string{"name", "visits", "share"},
+				Rows:      [][]string{{"home", "1,204", "12.5%"}, {"about", "87", ""}},
+				Alignment: []TableAlignment{AlignDefault, AlignRight, AlignRight},
+			},
+		},
+		{
+			name:  "no header detected",
+			input: "home,1204\nabout,87\n",
+			want: TableSet{
+				Header:    []string{"Column 1", "Column 2"},
+				Rows:      [][]string{{"home", "1204"}, {"about", "87"}},
+				Alignment: []TableAlignment{AlignDefault, AlignRight},
+			},
+		},
+		{
+			name:  "column selection",
+			input: "a;b;c\n1;x;3\n",
+			opts:  ImportOptions{Comma: ';', Columns: []string{"c", "a"}, NoAutoAlign: true},
+			want: TableSet{
+				Header:    []string{"c", "a"},
+				Rows:      [][]string{{"3", "1"}},
+				Alignment: []TableAlignment{AlignDefault, AlignDefault},
+			},
+		},
+		{
+			name:  "forced header",
+			input: "2023,2024\n1,2\n",
+			opts:  ImportOptions{Header: HeaderFirstRow},
+			want: TableSet{
+				Header:    []string{"2023", "2024"},
+				Rows:      [][]string{{"1", "2"}},
+				Alignment: []TableAlignment{AlignRight, AlignRight},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := TableFromCSV(strings.NewReader(tt.input), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("unexpected table set\nwant: %#v\ngot:  %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTableFromCSVErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := TableFromCSV(strings.NewReader("a,b\n1,2\n3\n"), ImportOptions{})
+	if !errors.Is(err, ErrMismatchColumn) {
+		t.Fatalf("expected ErrMismatchColumn, got %v", err)
+	}
+	if want := "line 3: " + ErrMismatchColumn.Error() + ": row 2 has 1 columns, want 2"; err.Error() != want {
+		t.Fatalf("unexpected error message\nwant: %q\ngot:  %q", want, err.Error())
+	}
+
+	_, err = TableFromCSV(strings.NewReader("a,b\n1,2\n"), ImportOptions{Columns: []string{"c"}})
+	if !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("expected ErrUnknownColumn, got %v", err)
+	}
+}
+
+func TestTableFromTSV(t *testing.T) {
+	t.Parallel()
+
+	got, err := TableFromTSV(strings.NewReader("name\tsize\n\"quoted\" file\t10\n"), ImportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := TableSet{
+		Header:    []string{"name", "size"},
+		Rows:      [][]string{{`"quoted" file`, "10"}},
+		Alignment: []TableAlignment{AlignDefault, AlignRight},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected table set\nwant: %#v\ngot:  %#v", want, got)
+	}
+}
+
+func TestTableFromJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("objects", func(t *testing.T) {
+		t.Parallel()
+
+		input := `[{"name": "api", "p99": 12.5}, {"name": "db", "tags": ["a", "b"], "p99": null, "up": true}]`
+		got, err := TableFromJSON(strings.NewReader(input), ImportOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := TableSet{
+			Header:    []string{"name", "p99", "tags", "up"},
+			Rows:      [][]string{{"api", "12.5", "", ""}, {"db", "", `["a","b"]`, "true"}},
+			Alignment: []TableAlignment{AlignDefault, AlignRight, AlignDefault, AlignDefault},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected table set\nwant: %#v\ngot:  %#v", want, got)
+		}
+	})
+
+	t.Run("arrays", func(t *testing.T) {
+		t.Parallel()
+
+		input := `[["name", "zip"], ["home", "02134"], ["work", 94105]]`
+		got, err := TableFromJSON(strings.NewReader(input), ImportOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// "02134" is a JSON string, so the column isn't numeric.
+		want := TableSet{
+			Header:    []string{"name", "zip"},
+			Rows:      [][]string{{"home", "02134"}, {"work", "94105"}},
+			Alignment: []TableAlignment{AlignDefault, AlignDefault},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected table set\nwant: %#v\ngot:  %#v", want, got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := TableFromJSON(strings.NewReader(`[["a", "b"], ["c"]]`), ImportOptions{})
+		if !errors.Is(err, ErrMismatchColumn) || !strings.HasPrefix(err.Error(), "element 2: ") {
+			t.Fatalf("expected ErrMismatchColumn at element 2, got %v", err)
+		}
+		_, err = TableFromJSON(strings.NewReader(`[["a"], {"a": 1}]`), ImportOptions{})
+		if !errors.Is(err, ErrInvalidJSONTable) {
+			t.Fatalf("expected ErrInvalidJSONTable, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package markdown
+
+import (
+	"fmt"
+
+	"github.com/yuin/goldmark/ast"
+	tableast "github.com/yuin/goldmark/extension/ast"
+)
+
+// Inline builds inline content such as emphasis, links and code spans as
+// goldmark AST nodes.
+type Inline struct {
+	parent ast.Node
+}
+
+func newInline(parent ast.Node) *Inline {
+	return &Inline{parent: parent}
+}
+
+func (i *Inline) append(node ast.Node) *Inline {
+	i.parent.AppendChild(i.parent, node)
+	return i
+}
+
+func (i *Inline) wrap(node ast.Node, fn func(*Inline)) *Inline {
+	fn(newInline(node))
+	return i.append(node)
+}
+
+func textNode(text string) ast.Node {
+	return ast.NewString([]byte(text))
+}
+
+// rawNode returns a string node holding trusted Markdown that is written
+// without escaping.
+func rawNode(markup string) ast.Node {
+	node := ast.NewString([]byte(markup))
+	node.SetRaw(true)
+	return node
+}
+
+// Text appends plain text.
+func (i *Inline) Text(text string) *Inline {
+	return i.append(textNode(text))
+}
+
+// Textf appends plain text with format.
+func (i *Inline) Textf(format string, args ...interface{}) *Inline {
+	return i.Text(fmt.Sprintf(format, args...))
+}
+
+// Raw appends trusted Markdown without escaping it.
+func (i *Inline) Raw(markup string) *Inline {
+	return i.append(rawNode(markup))
+}
+
+// Bold appends text with bold format.
+func (i *Inline) Bold(text string) *Inline {
+	return i.BoldFunc(func(b *Inline) { b.Text(text) })
+}
+
+// BoldFunc appends bold content built by fn.
+func (i *Inline) BoldFunc(fn func(*Inline)) *Inline {
+	return i.wrap(ast.NewEmphasis(2), fn)
+}
+
+// Italic appends text with italic format.
+func (i *Inline) Italic(text string) *Inline {
+	return i.ItalicFunc(func(e *Inline) { e.Text(text) })
+}
+
+// ItalicFunc appends italic content built by fn.
+func (i *Inline) ItalicFunc(fn func(*Inline)) *Inline {
+	return i.wrap(ast.NewEmphasis(1), fn)
+}
+
+// BoldItalic appends text with bold and italic format.
+func (i *Inline) BoldItalic(text string) *Inline {
+	return i.ItalicFunc(func(e *Inline) { e.Bold(text) })
+}
+
+// Strikethrough appends text with strikethrough format.
+func (i *Inline) Strikethrough(text string) *Inline {
+	return i.wrap(tableast.NewStrikethrough(), func(s *Inline) { s.Text(text) })
+}
+
+// Highlight appends text with highlight format.
+func (i *Inline) Highlight(text string) *Inline {
+	return i.wrap(newHighlightNode(), func(h *Inline) { h.Text(text) })
+}
+
+// Code appends a code span.
+func (i *Inline) Code(text string) *Inline {
+	span := ast.NewCodeSpan()
+	value := ast.NewString([]byte(text))
+	value.SetCode(true)
+	span.AppendChild(span, value)
+	return i.append(span)
+}
+
+// Link appends a link.
+func (i *Inline) Link(text, url string) *Inline {
+	return i.LinkFunc(url, func(l *Inline) { l.Text(text) })
+}
+
+// LinkFunc appends a link whose text is built by fn.
+func (i *Inline) LinkFunc(url string, fn func(*Inline)) *Inline {
+	link := ast.NewLink()
+	link.Destination = []byte(escapeLinkDestination(url))
+	return i.wrap(link, fn)
+}
+
+// Image appends an image.
+func (i *Inline) Image(text, url string) *Inline {
+	link := ast.NewLink()
+	link.Destination = []byte(escapeLinkDestination(url))
+	image := ast.NewImage(link)
+	return i.wrap(image, func(alt *Inline) { alt.Text(text) })
+}
+
+// Badge appends a shields.io badge image showing text on a background of
+// color, such as "red" or "brightgreen".
+func (i *Inline) Badge(text, color string) *Inline {
+	return i.append(badgeNode(text, color))
+}
+
+// CheckBox appends a checkbox. It is written as a task list marker in list
+// items and as a ballot box character in table cells.
+func (i *Inline) CheckBox(checked bool) *Inline {
+	return i.append(tableast.NewTaskCheckBox(checked))
+}
+
+// LineBreak appends a hard line break, written as <br> in table cells.
+func (i *Inline) LineBreak() *Inline {
+	return i.append(newLineBreakNode())
+}
+
+// Paragraph appends a paragraph built from inline content.
+func (m *Markdown) Paragraph(fn func(p *Inline)) *Markdown {
+	para := ast.NewParagraph()
+	fn(newInline(para))
+	m.appendBlock(para)
+	return m
+}
+
+// Heading appends a heading of the given level built from inline content.
+// A level outside 1 to 6 records an error and appends nothing.
+func (m *Markdown) Heading(level int, fn func(h *Inline)) *Markdown {
+	if level < 1 || level > 6 {
+		err := fmt.Errorf("%w: got %d", ErrInvalidHeadingLevel, level)
+		if m.err != nil {
+			m.err = fmt.Errorf("failed to add heading: %w: %s", err, m.err)
+		} else {
+			m.err = fmt.Errorf("failed to add heading: %w", err)
+		}
+		return m
+	}
+	heading := ast.NewHeading(level)
+	fn(newInline(heading))
+	text := m.newRenderer().collectPlainText(heading)
+	m.headers = append(m.headers, headerInfo{level: TableOfContentsDepth(level), text: text})
+	m.level = level
+	m.appendBlock(heading)
+	return m
+}
@@ -0,0 +1,76 @@
+package markdown
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestMarkdownParagraphInline(t *testing.T) {
+	t.Parallel()
+
+	md := NewMarkdown(io.Discard)
+	md.Paragraph(func(p *Inline) {
+		p.Text("see ").
+			Link("docs", "https://example.com").
+			Text(", ").
+			Bold("now").
+			Text(" or ").
+			Code("go test").
+			Text(" ").
+			Strikethrough("later").
+			Text(" ").
+			Highlight("soon").
+			Text(" ").
+			Image("logo", "logo.png")
+	})
+
+	want := "see [docs](https://example.com), **now** or `go test` ~~later~~ <mark>soon</mark> ![logo](logo.png)" + lineFeed()
+	if got := md.String(); got != want {
+		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+	}
+
+	html, err := md.HTML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantHTML := `<p>see <a href="https://example.com">docs</a>, <strong>now</strong> or <code>go test</code> <del>later</del> <mark>soon</mark> <img src="logo.png" alt="logo"></p>` + "\n"
+	if html != wantHTML {
+		t.Fatalf("unexpected html output\nwant: %q\ngot:  %q", wantHTML, html)
+	}
+}
+
+func TestMarkdownHeadingInline(t *testing.T) {
+	t.Parallel()
+
+	md := NewMarkdown(io.Discard)
+	md.Heading(2, func(h *Inline) {
+		h.Text("Using ").Code("Parse").Text(" with ").LinkFunc("https://example.com", func(l *Inline) {
+			l.ItalicFunc(func(i *Inline) { i.Text("goldmark") })
+		})
+	}).TableOfContents(TableOfContentsDepthH2)
+
+	lf := lineFeed()
+	want := "## Using `Parse` with [*goldmark*](https://example.com)" + lf + lf +
+		"  - [Using Parse with goldmark](#using-parse-with-goldmark)" + lf
+	if got := md.String(); got != want {
+		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+func TestMarkdownHeadingInvalidLevel(t *testing.T) {
+	t.Parallel()
+
+	for _, level := range []int{0, 7, 9} {
+		md := NewMarkdown(io.Discard).Heading(level, func(h *Inline) { h.Text("x") })
+		if err := md.Error(); !errors.Is(err, ErrInvalidHeadingLevel) {
+			t.Fatalf("level %d: expected ErrInvalidHeadingLevel, got %v", level, err)
+		}
+		if got := md.String(); got != "" {
+			t.Fatalf("level %d: unexpected markdown output: %q", level, got)
+		}
+		if len(md.headers) != 0 {
+			t.Fatalf("level %d: heading was recorded: %v", level, md.headers)
+		}
+	}
+}
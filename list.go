@@ -0,0 +1,160 @@
+package markdown
+
+import (
+	"fmt"
+
+	"github.com/yuin/goldmark/ast"
+	tableast "github.com/yuin/goldmark/extension/ast"
+)
+
+// ListBuilder builds the items of a bullet or ordered list. Items can hold
+// nested lists and any block content.
+type ListBuilder struct {
+	m    *Markdown
+	list *ast.List
+}
+
+// BulletListFunc appends an unordered list built by fn.
+func (m *Markdown) BulletListFunc(fn func(l *ListBuilder)) *Markdown {
+	m.appendBlock(m.buildList('-', fn))
+	return m
+}
+
+// OrderedListFunc appends an ordered list built by fn.
+func (m *Markdown) OrderedListFunc(fn func(l *ListBuilder)) *Markdown {
+	m.appendBlock(m.buildList('.', fn))
+	return m
+}
+
+func (m *Markdown) buildList(marker byte, fn func(l *ListBuilder)) *ast.List {
+	list := ast.NewList(marker)
+	if list.IsOrdered() {
+		list.Start = 1
+	}
+	fn(&ListBuilder{m: m, list: list})
+	finishList(list)
+	return list
+}
+
+// maxListStart is the largest number CommonMark reads as a list marker.
+const maxListStart = 999999999
+
+// Start sets the number of the first item of an ordered list. A number
+// outside 0 to 999999999 can't be a list marker; it records an error and
+// leaves the start unchanged.
+func (l *ListBuilder) Start(number int) *ListBuilder {
+	if number < 0 || number > maxListStart {
+		err := fmt.Errorf("%w: got %d", ErrInvalidListStart, number)
+		if l.m.err != nil {
+			l.m.err = fmt.Errorf("failed to set list start: %w: %s", err, l.m.err)
+		} else {
+			l.m.err = fmt.Errorf("failed to set list start: %w", err)
+		}
+		return l
+	}
+	if l.list.IsOrdered() {
+		l.list.Start = number
+	}
+	return l
+}
+
+// Item appends an item holding text.
+func (l *ListBuilder) Item(text string) *ListBuilder {
+	item := ast.NewListItem(0)
+	textBlock := ast.NewTextBlock()
+	textBlock.AppendChild(textBlock, textNode(text))
+	item.AppendChild(item, textBlock)
+	l.list.AppendChild(l.list, item)
+	return l
+}
+
+// Itemf appends an item holding text with format.
+func (l *ListBuilder) Itemf(format string, args ...interface{}) *ListBuilder {
+	return l.Item(fmt.Sprintf(format, args...))
+}
+
+// Task appends an item with a task list checkbox.
+func (l *ListBuilder) Task(checked bool, text string) *ListBuilder {
+	item := ast.NewListItem(0)
+	textBlock := ast.NewTextBlock()
+	textBlock.AppendChild(textBlock, tableast.NewTaskCheckBox(checked))
+	textBlock.AppendChild(textBlock, textNode(text))
+	item.AppendChild(item, textBlock)
+	l.list.AppendChild(l.list, item)
+	return l
+}
+
+// ItemFunc appends an item whose content is built by fn. The builder passed
+// to fn appends paragraphs, code blocks, tables and lists to the item.
+func (l *ListBuilder) ItemFunc(fn func(item *Markdown)) *ListBuilder {
+	item := ast.NewListItem(0)
+	l.m.scoped(item, fn)
+	l.list.AppendChild(l.list, item)
+	return l
+}
+
+// BulletList nests an unordered list built by fn under the last item.
+func (l *ListBuilder) BulletList(fn func(l *ListBuilder)) *ListBuilder {
+	item := l.lastItem()
+	item.AppendChild(item, l.m.buildList('-', fn))
+	return l
+}
+
+// OrderedList nests an ordered list built by fn under the last item.
+func (l *ListBuilder) OrderedList(fn func(l *ListBuilder)) *ListBuilder {
+	item := l.lastItem()
+	item.AppendChild(item, l.m.buildList('.', fn))
+	return l
+}
+
+func (l *ListBuilder) lastItem() ast.Node {
+	if last := l.list.LastChild(); last != nil {
+		return last
+	}
+	item := ast.NewListItem(0)
+	l.list.AppendChild(l.list, item)
+	return item
+}
+
+// finishList marks the list loose when an item holds blocks that can't
+// follow each other without a blank line, and converts item paragraphs to
+// match, as goldmark's parser does.
+func finishList(list *ast.List) {
+	list.IsTight = true
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		for child := item.FirstChild(); child != nil; child = child.NextSibling() {
+			if child == item.FirstChild() {
+				continue
+			}
+			if _, nested := child.(*ast.List); !nested {
+				list.IsTight = false
+			}
+		}
+	}
+
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		for child := item.FirstChild(); child != nil; {
+			next := child.NextSibling()
+			var replacement ast.Node
+			switch child.(type) {
+			case *ast.Paragraph:
+				if list.IsTight {
+					replacement = ast.NewTextBlock()
+				}
+			case *ast.TextBlock:
+				if !list.IsTight {
+					replacement = ast.NewParagraph()
+				}
+			}
+			if replacement != nil {
+				for inline := child.FirstChild(); inline != nil; {
+					nextInline := inline.NextSibling()
+					replacement.AppendChild(replacement, inline)
+					inline = nextInline
+				}
+				item.ReplaceChild(item, child, replacement)
+			}
+			child = next
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package markdown
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownNestedLists(t *testing.T) {
+	t.Parallel()
+
+	lf := lineFeed()
+
+	t.Run("tight nested lists", func(t *testing.T) {
+		md := NewMarkdown(io.Discard)
+		md.BulletListFunc(func(l *ListBuilder) {
+			l.Item("api").
+				OrderedList(func(o *ListBuilder) {
+					o.Start(4).Item("fix auth").Item("add paging")
+				}).
+				Item("cli").
+				BulletList(func(b *ListBuilder) {
+					b.Task(true, "flags").Task(false, "completion")
+				})
+		})
+		want := strings.Join([]string{
+			"- api",
+			"  4. fix auth",
+			"  5. add paging",
+			"- cli",
+			"  - [x] flags",
+			"  - [ ] completion",
+			"",
+		}, lf)
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("ordered lists can start at zero", func(t *testing.T) {
+		md := NewMarkdown(io.Discard)
+		md.OrderedListFunc(func(o *ListBuilder) {
+			o.Start(0).Item("zero").Item("one")
+		})
+		want := "0. zero" + lf + "1. one" + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+
+		parsed, err := Parse(io.Discard, []byte(want))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := parsed.String(); got != want {
+			t.Fatalf("unexpected parsed output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("start numbers outside the marker range are rejected", func(t *testing.T) {
+		for _, start := range []int{-3, 1000000000} {
+			md := NewMarkdown(io.Discard)
+			md.OrderedListFunc(func(o *ListBuilder) {
+				o.Start(start).Item("a")
+			})
+			if err := md.Error(); !errors.Is(err, ErrInvalidListStart) {
+				t.Fatalf("start %d: expected ErrInvalidListStart, got %v", start, err)
+			}
+			if want, got := "1. a"+lf, md.String(); got != want {
+				t.Fatalf("start %d: unexpected markdown output\nwant: %q\ngot:  %q", start, want, got)
+			}
+		}
+
+		md := NewMarkdown(io.Discard)
+		md.OrderedListFunc(func(o *ListBuilder) {
+			o.Start(999999999).Item("a").Item("b")
+		})
+		want := "999999999. a" + lf + "999999999. b" + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("multi-block items make the list loose", func(t *testing.T) {
+		md := NewMarkdown(io.Discard)
+		md.OrderedListFunc(func(l *ListBuilder) {
+			l.Item("Install").ItemFunc(func(item *Markdown) {
+				item.PlainText("Run the tool:").
+					CodeBlocks(SyntaxHighlightShell, "go run .").
+					Table(TableSet{Header: []string{"Flag"}, Rows: [][]string{{"-v"}}})
+			})
+		})
+		want := strings.Join([]string{
+			"1. Install",
+			"",
+			"2. Run the tool:",
+			"",
+			"   ```shell",
+			"   go run .",
+			"   ```",
+			"",
+			"   | Flag |",
+			"   | ---- |",
+			"   | -v   |",
+			"",
+		}, lf)
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+
+		html, err := md.HTML()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(html, "<li>\n<p>Run the tool:</p>\n<pre><code") {
+			t.Fatalf("expected loose item with a code block, got %q", html)
+		}
+	})
+}
@@ -94,23 +94,63 @@ type TableSet struct {
 	Header    []string
 	Rows      [][]string
 	Alignment []TableAlignment
+	// Footer is an optional last row, such as totals, rendered in bold.
+	Footer []string
+	// Caption, HeaderGroups and Spans can't be expressed by pipe tables;
+	// Table writes sets using them as HTML tables.
+	Caption string
+	// HeaderGroups adds a header row above Header whose cells span columns.
+	HeaderGroups []ColumnGroup
+	// Spans merges body cells across columns and rows.
+	Spans []CellSpan
 }
 
-// ValidateColumns checks if the number of columns in the header and records match.
+// ValidateColumns checks if the number of columns in the header, records and
+// footer match. A mismatch is reported as a *ColumnMismatchError, which
+// matches ErrMismatchColumn.
 func (t *TableSet) ValidateColumns() error {
 	headerColumns := len(t.Header)
-	for _, record := range t.Rows {
+	for i, record := range t.Rows {
 		if len(record) != headerColumns {
-			return ErrMismatchColumn
+			return &ColumnMismatchError{Row: i, Columns: len(record), Want: headerColumns}
 		}
 	}
+	if t.Footer != nil && len(t.Footer) != headerColumns {
+		return &ColumnMismatchError{Row: len(t.Rows), Footer: true, Columns: len(t.Footer), Want: headerColumns}
+	}
 	return nil
 }
 
 // TableOptions controls formatting when rendering custom tables.
 type TableOptions struct {
+	// AutoWrapText wraps body cells wider than MaxColumnWidth at word
+	// boundaries, joining the lines with <br>.
 	AutoWrapText      bool
 	AutoFormatHeaders bool
+	// RawCells writes cell values as trusted Markdown without escaping.
+	RawCells bool
+	// MaxColumnWidth is the width AutoWrapText fits cells to, counting the
+	// escapes they are written with. Zero means 40.
+	MaxColumnWidth int
+	// Truncate makes AutoWrapText cut cells at MaxColumnWidth with an
+	// ellipsis instead of wrapping them.
+	Truncate bool
+	// Formatters rewrite the body and footer cells of the columns with the
+	// given headers, such as FormatThousands(0) or FormatBytes().
+	Formatters map[string]CellFormatter
+	// Styles decorate the body cells of the columns with the given headers
+	// when their value matches.
+	Styles map[string][]CellStyle
+	// MaxTableWidth splits tables whose lines are wider than it into
+	// several tables, each repeating the first KeyColumns columns. Zero
+	// leaves tables whole.
+	MaxTableWidth int
+	KeyColumns    int
+	// TransposeWide writes tables wider than MaxTableWidth transposed
+	// instead of splitting them, when the transposed table fits. Styles
+	// follow their cells, except in the first column, which becomes the
+	// header; the footer becomes the last column, in bold.
+	TransposeWide bool
 }
 
 // CheckBoxSet configures a single checkbox entry.
@@ -121,28 +161,62 @@ type CheckBoxSet struct {
 
 // Markdown is markdown text.
 type Markdown struct {
-	doc     *ast.Document
-	dest    io.Writer
-	err     error
-	headers []headerInfo
+	doc *ast.Document
+	// container receives appended blocks when the builder is scoped to a
+	// node inside doc, such as a list item.
+	container ast.Node
+	source    []byte
+	dest      io.Writer
+	err       error
+	headers   []headerInfo
+	// level is the level of the last heading added to the document itself,
+	// which included documents are placed below.
+	level   int
+	options options
 }
 
 func (m *Markdown) appendBlock(node ast.Node) {
+	if m.container != nil {
+		m.container.AppendChild(m.container, node)
+		return
+	}
 	m.doc.AppendChild(m.doc, node)
 }
 
+// scoped calls fn with a builder that appends blocks to container while
+// sharing the document, options, headers and error of m.
+func (m *Markdown) scoped(container ast.Node, fn func(*Markdown)) {
+	child := &Markdown{
+		doc:       m.doc,
+		container: container,
+		source:    m.source,
+		dest:      m.dest,
+		err:       m.err,
+		headers:   m.headers,
+		level:     m.level,
+		options:   m.options,
+	}
+	fn(child)
+	m.err = child.err
+	m.headers = child.headers
+	m.source = child.source
+	m.level = child.level
+}
+
 // NewMarkdown returns new Markdown.
-func NewMarkdown(w io.Writer) *Markdown {
+func NewMarkdown(w io.Writer, opts ...Option) *Markdown {
 	return &Markdown{
 		doc:     ast.NewDocument(),
 		dest:    w,
 		headers: []headerInfo{},
+		options: newOptions(opts),
 	}
 }
 
 // String returns markdown text.
 func (m *Markdown) String() string {
-	return m.renderMarkdown()
+	out, _ := m.renderMarkdown()
+	return out
 }
 
 // Error returns error.
@@ -150,7 +224,8 @@ func (m *Markdown) Error() error {
 	return m.err
 }
 
-// PlainText set plain text
+// PlainText set plain text. Markdown syntax in text is escaped unless the
+// builder was created with WithoutEscaping; use Raw for trusted markup.
 func (m *Markdown) PlainText(text string) *Markdown {
 	para := ast.NewParagraph()
 	para.AppendChild(para, ast.NewString([]byte(text)))
@@ -163,9 +238,28 @@ func (m *Markdown) PlainTextf(format string, args ...interface{}) *Markdown {
 	return m.PlainText(fmt.Sprintf(format, args...))
 }
 
-// Build writes markdown text to output destination.
+// Raw appends trusted markdown text as is, without escaping.
+func (m *Markdown) Raw(markup string) *Markdown {
+	m.appendBlock(newLiteralBlock(markup))
+	return m
+}
+
+// Rawf appends trusted markdown text with format, without escaping.
+func (m *Markdown) Rawf(format string, args ...interface{}) *Markdown {
+	return m.Raw(fmt.Sprintf(format, args...))
+}
+
+// Build writes markdown text to output destination. With WithStrictFlavor,
+// nothing is written when the document can't be expressed in the flavor.
 func (m *Markdown) Build() error {
-	if _, err := fmt.Fprint(m.dest, m.String()); err != nil {
+	out, err := m.renderMarkdown()
+	if err != nil {
+		if m.err != nil {
+			return fmt.Errorf("failed to render markdown text: %w: %s", err, m.err.Error())
+		}
+		return fmt.Errorf("failed to render markdown text: %w", err)
+	}
+	if _, err := fmt.Fprint(m.dest, out); err != nil {
 		if m.err != nil {
 			return fmt.Errorf("failed to write markdown text: %w: %s", err, m.err.Error())
 		}
@@ -178,6 +272,7 @@ func (m *Markdown) addHeading(level int, text string) *Markdown {
 	heading := ast.NewHeading(level)
 	heading.AppendChild(heading, ast.NewString([]byte(text)))
 	m.headers = append(m.headers, headerInfo{level: TableOfContentsDepth(level), text: text})
+	m.level = level
 	m.appendBlock(heading)
 	return m
 }
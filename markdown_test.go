@@ -15,18 +15,75 @@ func TestMarkdownHeadingsAndTOC(t *testing.T) {
 		TableOfContents(TableOfContentsDepthH2)
 
 	lf := lineFeed()
-	want := "# Introduction" + lf +
-		"## Overview" + lf +
-		"### Details" + lf +
+	want := "# Introduction" + lf + lf +
+		"## Overview" + lf + lf +
+		"### Details" + lf + lf +
 		"- [Introduction](#introduction)" + lf +
-		"  - [Overview](#overview)" + lf +
-		""
+		"  - [Overview](#overview)" + lf
 
 	if got := md.String(); got != want {
 		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
 	}
 }
 
+func TestMarkdownBlockSeparation(t *testing.T) {
+	t.Parallel()
+
+	lf := lineFeed()
+
+	t.Run("blank lines between blocks", func(t *testing.T) {
+		md := NewMarkdown(io.Discard)
+		md.PlainText("First paragraph").
+			PlainText("Second paragraph").
+			LF().
+			Table(TableSet{Header: []string{"Name"}, Rows: [][]string{{"Alice"}}}).
+			Blockquote("Quoted" + lf + "Lines")
+		want := "First paragraph" + lf + lf +
+			"Second paragraph" + lf + lf +
+			"| Name  |" + lf +
+			"| ----- |" + lf +
+			"| Alice |" + lf + lf +
+			"> Quoted" + lf +
+			"> Lines" + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("adjacent lists stay separate", func(t *testing.T) {
+		md := NewMarkdown(io.Discard)
+		md.BulletList("a", "b").BulletList("c").OrderedList("d")
+		want := "- a" + lf + "- b" + lf + lf +
+			"<!-- -->" + lf + lf +
+			"- c" + lf + lf +
+			"1. d" + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("compact blocks", func(t *testing.T) {
+		md := NewMarkdown(io.Discard, WithCompactBlocks())
+		md.H1("Introduction").
+			H2("Overview").
+			TableOfContents(TableOfContentsDepthH2).
+			PlainText("Body").
+			Table(TableSet{Header: []string{"Name"}, Rows: [][]string{{"Alice"}}})
+		want := "# Introduction" + lf +
+			"## Overview" + lf +
+			"- [Introduction](#introduction)" + lf +
+			"  - [Overview](#overview)" + lf +
+			lf +
+			"Body" + lf +
+			"| Name  |" + lf +
+			"| ----- |" + lf +
+			"| Alice |" + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+}
+
 func TestMarkdownLists(t *testing.T) {
 	t.Parallel()
 
@@ -35,7 +92,7 @@ func TestMarkdownLists(t *testing.T) {
 	t.Run("bullet list", func(t *testing.T) {
 		md := NewMarkdown(io.Discard)
 		md.BulletList("Hello", "World")
-		want := "- Hello" + lf + "- World"
+		want := "- Hello" + lf + "- World" + lf
 		if got := md.String(); got != want {
 			t.Fatalf("unexpected bullet list output\nwant: %q\ngot:  %q", want, got)
 		}
@@ -44,7 +101,7 @@ func TestMarkdownLists(t *testing.T) {
 	t.Run("ordered list", func(t *testing.T) {
 		md := NewMarkdown(io.Discard)
 		md.OrderedList("First", "Second")
-		want := "1. First" + lf + "2. Second"
+		want := "1. First" + lf + "2. Second" + lf
 		if got := md.String(); got != want {
 			t.Fatalf("unexpected ordered list output\nwant: %q\ngot:  %q", want, got)
 		}
@@ -53,7 +110,7 @@ func TestMarkdownLists(t *testing.T) {
 	t.Run("checkbox list", func(t *testing.T) {
 		md := NewMarkdown(io.Discard)
 		md.CheckBox([]CheckBoxSet{{Text: "Task", Checked: true}, {Text: "Review", Checked: false}})
-		want := "- [x] Task" + lf + "- [ ] Review"
+		want := "- [x] Task" + lf + "- [ ] Review" + lf
 		if got := md.String(); got != want {
 			t.Fatalf("unexpected checkbox list output\nwant: %q\ngot:  %q", want, got)
 		}
@@ -116,4 +173,38 @@ func TestMarkdownTableRenderingVariants(t *testing.T) {
 			t.Fatalf("unexpected custom table output\nwant: %q\ngot:  %q", want, got)
 		}
 	})
+
+	t.Run("auto wrap text", func(t *testing.T) {
+		md := NewMarkdown(io.Discard)
+		md.CustomTable(TableSet{
+			Header: []string{"Code", "Message"},
+			Rows:   [][]string{{"E1", "the quick brown fox jumps"}, {"E2", "short"}},
+		}, TableOptions{AutoWrapText: true, MaxColumnWidth: 10})
+
+		want := "| Code | Message   |" + lf +
+			"| ---- | --------- |" + lf +
+			"| E1   | the quick<br>brown fox<br>jumps |" + lf +
+			"| E2   | short     |" + lf
+
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected wrapped table output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("auto wrap text truncate", func(t *testing.T) {
+		md := NewMarkdown(io.Discard)
+		md.CustomTable(TableSet{
+			Header: []string{"Code", "Message"},
+			Rows:   [][]string{{"E1", "the quick brown fox jumps"}, {"E2", "incomprehensibilities"}},
+		}, TableOptions{AutoWrapText: true, MaxColumnWidth: 10, Truncate: true})
+
+		want := "| Code | Message    |" + lf +
+			"| ---- | ---------- |" + lf +
+			"| E1   | the quick… |" + lf +
+			"| E2   | incompreh… |" + lf
+
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected truncated table output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
 }
@@ -5,6 +5,12 @@ import "github.com/yuin/goldmark/ast"
 var (
 	kindLiteralBlock = ast.NewNodeKind("MarkdownLiteralBlock")
 	kindCodeBlock    = ast.NewNodeKind("MarkdownCodeBlock")
+	kindCallout      = ast.NewNodeKind("MarkdownCallout")
+	kindHighlight    = ast.NewNodeKind("MarkdownHighlight")
+	kindTOC          = ast.NewNodeKind("MarkdownTableOfContents")
+	kindDetails      = ast.NewNodeKind("MarkdownDetails")
+	kindLineBreak    = ast.NewNodeKind("MarkdownLineBreak")
+	kindFrontMatter  = ast.NewNodeKind("MarkdownFrontMatter")
 )
 
 type literalBlock struct {
@@ -45,3 +51,114 @@ func (n *codeBlockNode) Dump(source []byte, level int) {
 	}
 	ast.DumpHelper(n, source, level, meta, nil)
 }
+
+type calloutBlock struct {
+	ast.BaseBlock
+	label string
+}
+
+func newCalloutBlock(label string) *calloutBlock {
+	return &calloutBlock{label: label}
+}
+
+func (n *calloutBlock) Kind() ast.NodeKind {
+	return kindCallout
+}
+
+func (n *calloutBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Label": n.label}, nil)
+}
+
+// detailsBlock is a collapsible block with a summary line.
+type detailsBlock struct {
+	ast.BaseBlock
+	summary string
+}
+
+func newDetailsBlock(summary string) *detailsBlock {
+	return &detailsBlock{summary: summary}
+}
+
+func (n *detailsBlock) Kind() ast.NodeKind {
+	return kindDetails
+}
+
+func (n *detailsBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Summary": n.summary}, nil)
+}
+
+// tocBlock is a table of contents placeholder. Its children are rebuilt from
+// the document headings before every render.
+type tocBlock struct {
+	ast.BaseBlock
+	options TableOfContentsOptions
+	// headings limits the entries to the headings of an included document,
+	// whose levels were raised by shift. Nil lists every heading.
+	headings map[*ast.Heading]bool
+	shift    int
+}
+
+func newTOCBlock(options TableOfContentsOptions) *tocBlock {
+	return &tocBlock{options: options}
+}
+
+func (n *tocBlock) Kind() ast.NodeKind {
+	return kindTOC
+}
+
+func (n *tocBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Title": n.options.Title}, nil)
+}
+
+type highlightNode struct {
+	ast.BaseInline
+}
+
+func newHighlightNode() *highlightNode {
+	return &highlightNode{}
+}
+
+func (n *highlightNode) Kind() ast.NodeKind {
+	return kindHighlight
+}
+
+func (n *highlightNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// lineBreakNode is a hard line break, written as <br> in table cells.
+type lineBreakNode struct {
+	ast.BaseInline
+}
+
+func newLineBreakNode() *lineBreakNode {
+	return &lineBreakNode{}
+}
+
+func (n *lineBreakNode) Kind() ast.NodeKind {
+	return kindLineBreak
+}
+
+func (n *lineBreakNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// frontMatterBlock holds the front matter of a document without its
+// delimiter lines.
+type frontMatterBlock struct {
+	ast.BaseBlock
+	format FrontMatterFormat
+	text   string
+}
+
+func newFrontMatterBlock(format FrontMatterFormat, text string) *frontMatterBlock {
+	return &frontMatterBlock{format: format, text: text}
+}
+
+func (n *frontMatterBlock) Kind() ast.NodeKind {
+	return kindFrontMatter
+}
+
+func (n *frontMatterBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Text": n.text}, nil)
+}
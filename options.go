@@ -0,0 +1,149 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// Option configures how a Markdown builder renders its document.
+type Option func(*options)
+
+type options struct {
+	compactBlocks  bool
+	rawText        bool
+	newSlugger     func() Slugger
+	lineEnding     string
+	bulletMarker   byte
+	emphasisStyle  EmphasisStyle
+	headingStyle   HeadingStyle
+	codeFence      string
+	noTablePadding bool
+	flavor         Flavor
+	strictFlavor   bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithCompactBlocks separates blocks with a single line feed instead of a
+// blank line. This reproduces the output of github.com/nao1215/markdown, but
+// CommonMark parsers merge consecutive paragraphs rendered this way.
+func WithCompactBlocks() Option {
+	return func(o *options) {
+		o.compactBlocks = true
+	}
+}
+
+// WithoutEscaping writes text values verbatim, treating them as trusted
+// Markdown as github.com/nao1215/markdown does. By default Markdown syntax in
+// text is escaped for the context it is written in.
+func WithoutEscaping() Option {
+	return func(o *options) {
+		o.rawText = true
+	}
+}
+
+// EmphasisStyle is the delimiter used for emphasis and strong emphasis.
+type EmphasisStyle int
+
+const (
+	// EmphasisStyleAsterisk writes *italic* and **bold**. This is the default.
+	EmphasisStyleAsterisk EmphasisStyle = iota
+	// EmphasisStyleUnderscore writes _italic_ and __bold__. Asterisks are
+	// still used inside words, where underscores don't delimit emphasis.
+	EmphasisStyleUnderscore
+)
+
+// HeadingStyle is the syntax used for headings.
+type HeadingStyle int
+
+const (
+	// HeadingStyleATX writes headings prefixed with "#". This is the default.
+	HeadingStyleATX HeadingStyle = iota
+	// HeadingStyleSetext underlines level 1 and 2 headings with "=" and "-".
+	// Deeper headings, which have no setext form, are written as ATX.
+	HeadingStyleSetext
+)
+
+// WithLineEnding sets the line ending of the rendered Markdown, such as "\n"
+// or "\r\n". By default "\r\n" is used on Windows and "\n" elsewhere.
+func WithLineEnding(lineEnding string) Option {
+	return func(o *options) {
+		o.lineEnding = lineEnding
+	}
+}
+
+// WithBulletMarker sets the marker of bullet list items: '-', '*' or '+'.
+// Other values are ignored. The default is '-'.
+func WithBulletMarker(marker byte) Option {
+	return func(o *options) {
+		switch marker {
+		case '-', '*', '+':
+			o.bulletMarker = marker
+		}
+	}
+}
+
+// WithEmphasisStyle sets the delimiter of italic and bold text.
+func WithEmphasisStyle(style EmphasisStyle) Option {
+	return func(o *options) {
+		o.emphasisStyle = style
+	}
+}
+
+// WithHeadingStyle sets the syntax of headings.
+func WithHeadingStyle(style HeadingStyle) Option {
+	return func(o *options) {
+		o.headingStyle = style
+	}
+}
+
+// WithCodeFence sets the fence of code blocks, three or more backticks or
+// tildes such as "~~~". Other values are ignored. The fence is lengthened
+// when the code contains it.
+func WithCodeFence(fence string) Option {
+	return func(o *options) {
+		if len(fence) >= 3 && (strings.Trim(fence, "`") == "" || strings.Trim(fence, "~") == "") {
+			o.codeFence = fence
+		}
+	}
+}
+
+// WithTablePadding sets whether table cells are padded to align columns.
+// Padding is enabled by default; disabling it keeps diffs of generated
+// tables to the rows that changed.
+func WithTablePadding(padding bool) Option {
+	return func(o *options) {
+		o.noTablePadding = !padding
+	}
+}
+
+func (o options) lineFeed() string {
+	if o.lineEnding != "" {
+		return o.lineEnding
+	}
+	return lineFeed()
+}
+
+// listMarker returns the marker written for list, which for bullet lists is
+// the configured marker rather than the one the list was built or parsed
+// with.
+func (o options) listMarker(list *ast.List) byte {
+	if !list.IsOrdered() && o.bulletMarker != 0 {
+		return o.bulletMarker
+	}
+	return list.Marker
+}
+
+func (o options) fence() string {
+	if o.codeFence != "" {
+		return o.codeFence
+	}
+	return "```"
+}
@@ -0,0 +1,114 @@
+package markdown
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestOutputStyleOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		md := NewMarkdown(io.Discard, WithLineEnding("\n")).
+			H1("Title").
+			Paragraph(func(p *Inline) { p.Bold("bold").Text(" and ").Italic("italic") }).
+			BulletList("a").
+			CodeBlocks(SyntaxHighlightGo, "x := 1")
+
+		want := "# Title\n\n**bold** and *italic*\n\n- a\n\n```go\nx := 1\n```\n"
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("line ending", func(t *testing.T) {
+		t.Parallel()
+
+		md, err := Parse(io.Discard, []byte("one\ntwo\n\n- a\n"), WithLineEnding("\r\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "one\r\ntwo\r\n\r\n- a\r\n"
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("bullet marker", func(t *testing.T) {
+		t.Parallel()
+
+		md, err := Parse(io.Discard, []byte("- a\n\n* b\n"), WithLineEnding("\n"), WithBulletMarker('*'))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Both lists now share a marker, so they must stay apart.
+		want := "* a\n\n<!-- -->\n\n* b\n"
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("emphasis style", func(t *testing.T) {
+		t.Parallel()
+
+		md := NewMarkdown(io.Discard, WithLineEnding("\n"), WithEmphasisStyle(EmphasisStyleUnderscore)).
+			Paragraph(func(p *Inline) {
+				p.Bold("bold").Text(" and ").Italic("italic").Text(" in").Italic("word")
+			})
+
+		want := "__bold__ and _italic_ in*word*\n"
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("heading style", func(t *testing.T) {
+		t.Parallel()
+
+		md := NewMarkdown(io.Discard, WithLineEnding("\n"), WithHeadingStyle(HeadingStyleSetext)).
+			H1("Title").
+			H2("- Item").
+			H3("Deep")
+
+		want := "Title\n=====\n\n\\- Item\n-------\n\n### Deep\n"
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("code fence", func(t *testing.T) {
+		t.Parallel()
+
+		md := NewMarkdown(io.Discard, WithLineEnding("\n"), WithCodeFence("~~~")).
+			CodeBlocks(SyntaxHighlightNone, "~~~").
+			CodeBlocks(SyntaxHighlightGo, "x := 1")
+
+		want := "~~~~\n~~~\n~~~~\n\n~~~go\nx := 1\n~~~\n"
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("table padding", func(t *testing.T) {
+		t.Parallel()
+
+		md := NewMarkdown(io.Discard, WithLineEnding("\n"), WithTablePadding(false)).
+			Table(TableSet{
+				Header:    []string{"Name", "Count"},
+				Rows:      [][]string{{"alpha", "2"}},
+				Alignment: []TableAlignment{AlignLeft, AlignRight},
+			})
+
+		want := strings.Join([]string{
+			"| Name | Count |",
+			"| :-- | --: |",
+			"| alpha | 2 |",
+		}, "\n") + "\n"
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package markdown
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	tableast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// gfmExtensions are the goldmark extensions matching the constructs the builder emits.
+var gfmExtensions = []goldmark.Extender{extension.Table, extension.Strikethrough, extension.TaskList}
+
+// gfmMarkdown parses existing documents and converts the pre-rendered
+// Markdown held by literal blocks and inline strings into HTML.
+var gfmMarkdown = goldmark.New(
+	goldmark.WithExtensions(gfmExtensions...),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// Parse returns a Markdown populated from existing markdown source, so that
+// further content can be appended before writing it to w. A leading YAML
+// (---) or TOML (+++) front matter block is kept as written and can be read
+// with FrontMatterValues.
+func Parse(w io.Writer, src []byte, opts ...Option) (*Markdown, error) {
+	if !utf8.Valid(src) {
+		return nil, ErrInvalidEncoding
+	}
+	frontMatter, body := splitFrontMatter(src)
+	source := append([]byte(nil), body...)
+	doc, ok := gfmMarkdown.Parser().Parse(text.NewReader(source)).(*ast.Document)
+	if !ok {
+		return nil, ErrParseMarkdown
+	}
+	if frontMatter != nil {
+		if first := doc.FirstChild(); first != nil {
+			doc.InsertBefore(doc, first, frontMatter)
+		} else {
+			doc.AppendChild(doc, frontMatter)
+		}
+	}
+
+	m := NewMarkdown(w, opts...)
+	m.doc = doc
+	m.source = source
+
+	r := m.newRenderer()
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if h, ok := node.(*ast.Heading); ok && entering {
+			m.headers = append(m.headers, headerInfo{level: TableOfContentsDepth(h.Level), text: r.collectPlainText(h)})
+			m.level = h.Level
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return m, nil
+}
+
+// Load reads markdown from src and returns it as a Markdown that writes to w.
+func Load(w io.Writer, src io.Reader, opts ...Option) (*Markdown, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read markdown: %w", err)
+	}
+	return Parse(w, data, opts...)
+}
+
+// ParseTable parses the first table in Markdown source. Cells hold their
+// text, with escapes resolved and <br> read as a line break, so Table writes
+// them back unchanged. Inline formatting such as links, emphasis, code spans
+// and HTML is dropped; use ParseTableRaw to keep it.
+func ParseTable(src string) (TableSet, error) {
+	return firstTable(ParseTables(src))
+}
+
+// ParseTableRaw parses the first table in Markdown source like ParseTable,
+// but cells hold their Markdown, inline formatting included. Write the set
+// back with CustomTable and TableOptions.RawCells.
+func ParseTableRaw(src string) (TableSet, error) {
+	return firstTable(ParseTablesRaw(src))
+}
+
+func firstTable(sets []TableSet, err error) (TableSet, error) {
+	if err != nil {
+		return TableSet{}, err
+	}
+	if len(sets) == 0 {
+		return TableSet{}, ErrNoTable
+	}
+	return sets[0], nil
+}
+
+// ParseTables parses every table in Markdown source, including tables
+// nested in lists and blockquotes, in document order.
+func ParseTables(src string) ([]TableSet, error) {
+	m, err := Parse(io.Discard, []byte(src))
+	if err != nil {
+		return nil, err
+	}
+	return m.Tables(), nil
+}
+
+// ParseTablesRaw parses every table in Markdown source like ParseTables,
+// with cells holding their Markdown as ParseTableRaw does.
+func ParseTablesRaw(src string) ([]TableSet, error) {
+	m, err := Parse(io.Discard, []byte(src))
+	if err != nil {
+		return nil, err
+	}
+	return m.RawTables(), nil
+}
+
+// Tables returns the content of every table in the document in order, with
+// cells holding their text as ParseTable does.
+func (m *Markdown) Tables() []TableSet {
+	return m.tables(false)
+}
+
+// RawTables returns every table in the document in order, with cells
+// holding their Markdown as ParseTableRaw does.
+func (m *Markdown) RawTables() []TableSet {
+	return m.tables(true)
+}
+
+func (m *Markdown) tables(raw bool) []TableSet {
+	r := m.newRenderer()
+	var sets []TableSet
+	_ = ast.Walk(m.doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if table, ok := node.(*tableast.Table); ok && entering {
+			sets = append(sets, r.tableSet(table, raw))
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return sets
+}
+
+func (r *markdownRenderer) tableSet(table *tableast.Table, raw bool) TableSet {
+	set := TableSet{Rows: [][]string{}}
+	for child := table.FirstChild(); child != nil; child = child.NextSibling() {
+		var cells []string
+		for cell := child.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, r.cellValue(cell, raw))
+		}
+		if _, ok := child.(*tableast.TableHeader); ok {
+			set.Header = cells
+			continue
+		}
+		// Rows are cut or padded to the header, as GFM renders them.
+		row := make([]string, len(set.Header))
+		copy(row, cells)
+		set.Rows = append(set.Rows, row)
+	}
+
+	set.Alignment = make([]TableAlignment, len(set.Header))
+	for i := range set.Alignment {
+		if i >= len(table.Alignments) {
+			break
+		}
+		switch table.Alignments[i] {
+		case tableast.AlignLeft:
+			set.Alignment[i] = AlignLeft
+		case tableast.AlignCenter:
+			set.Alignment[i] = AlignCenter
+		case tableast.AlignRight:
+			set.Alignment[i] = AlignRight
+		}
+	}
+	return set
+}
+
+// cellValue returns the text of a table cell, or the Markdown it is written
+// with when raw.
+func (r *markdownRenderer) cellValue(cell ast.Node, raw bool) string {
+	if c, ok := cell.(*tableast.TableCell); ok && raw {
+		return r.collectCellText(c)
+	}
+	r.inCell = true
+	defer func() { r.inCell = false }()
+	return r.collectPlainText(cell)
+}
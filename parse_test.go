@@ -0,0 +1,168 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAppendsToExistingDocument(t *testing.T) {
+	t.Parallel()
+
+	src := "# Changelog\n" +
+		"Notes with *emphasis*, **strong**, `code` and [docs](https://example.com \"Docs\").\n" +
+		"- fixed ~~bug~~\n" +
+		"  1. nested\n" +
+		"- [x] released\n" +
+		"```go\nfunc main() {}\n```\n" +
+		"| Name | Count |\n| :--- | ----: |\n| `a\\|b` | 2 |\n"
+
+	var buf bytes.Buffer
+	md, err := Parse(&buf, []byte(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md.H2("v1.1.0").BulletList("Added parsing")
+	if err := md.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lf := lineFeed()
+	want := strings.Join([]string{
+		"# Changelog",
+		"",
+		"Notes with *emphasis*, **strong**, `code` and [docs](https://example.com \"Docs\").",
+		"",
+		"- fixed ~~bug~~",
+		"  1. nested",
+		"- [x] released",
+		"",
+		"```go",
+		"func main() {}",
+		"```",
+		"",
+		"| Name   | Count |",
+		"| :----- | ----: |",
+		"| `a\\|b` |     2 |",
+		"",
+		"## v1.1.0",
+		"",
+		"- Added parsing",
+		"",
+	}, lf)
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+	}
+
+	wantHeaders := []headerInfo{{level: 1, text: "Changelog"}, {level: 2, text: "v1.1.0"}}
+	if len(md.headers) != len(wantHeaders) {
+		t.Fatalf("unexpected headers: %v", md.headers)
+	}
+	for i, h := range wantHeaders {
+		if md.headers[i] != h {
+			t.Fatalf("unexpected header %d: want %v, got %v", i, h, md.headers[i])
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	md, err := Load(io.Discard, strings.NewReader("## Usage\n<div>\nraw\n</div>\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md.TableOfContents(TableOfContentsDepthH2)
+
+	lf := lineFeed()
+	want := "## Usage" + lf + lf + "<div>" + lf + "raw" + lf + "</div>" + lf + lf + "  - [Usage](#usage)" + lf
+	if got := md.String(); got != want {
+		t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+func TestParseInvalidEncoding(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Parse(io.Discard, []byte{0xff, 0xfe}); !errors.Is(err, ErrInvalidEncoding) {
+		t.Fatalf("expected ErrInvalidEncoding, got %v", err)
+	}
+}
+
+func TestParseTable(t *testing.T) {
+	t.Parallel()
+
+	src := "Intro\n\n" +
+		"| Service | Owner | `a\\|b` | Cost |\n" +
+		"| :------ | :---: | ------ | ---: |\n" +
+		"| **api** | [ops](https://ops) | x \\| y | 1<br>2 |\n" +
+		"| db |\n"
+
+	set, err := ParseTable(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := TableSet{
+		Header:    []string{"Service", "Owner", "a|b", "Cost"},
+		Rows:      [][]string{{"api", "ops", "x | y", "1\n2"}, {"db", "", "", ""}},
+		Alignment: []TableAlignment{AlignLeft, AlignCenter, AlignDefault, AlignRight},
+	}
+	if !reflect.DeepEqual(set, want) {
+		t.Fatalf("unexpected table set\nwant: %#v\ngot:  %#v", want, set)
+	}
+
+	// Writing the table back and parsing it again yields the same set.
+	again, err := ParseTable(NewMarkdown(io.Discard).Table(set).String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(again, want) {
+		t.Fatalf("unexpected round trip\nwant: %#v\ngot:  %#v", want, again)
+	}
+}
+
+func TestParseTableRaw(t *testing.T) {
+	t.Parallel()
+
+	src := "| Service | Owner | Notes |\n" +
+		"| ------- | ----- | ----- |\n" +
+		"| **api** | [ops](https://ops) | `a\\|b` x \\| y<br>*new* |\n"
+
+	set, err := ParseTableRaw(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]string{{"**api**", "[ops](https://ops)", "`a\\|b` x \\| y<br>*new*"}}
+	if !reflect.DeepEqual(set.Rows, want) {
+		t.Fatalf("unexpected rows\nwant: %q\ngot:  %q", want, set.Rows)
+	}
+
+	got := NewMarkdown(io.Discard, WithLineEnding("\n")).CustomTable(set, TableOptions{RawCells: true}).String()
+	again, err := ParseTableRaw(got)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(again, set) {
+		t.Fatalf("unexpected round trip\nwant: %#v\ngot:  %#v", set, again)
+	}
+}
+
+func TestParseTables(t *testing.T) {
+	t.Parallel()
+
+	src := "| A |\n| - |\n| 1 |\n\n- item\n\n  | B |\n  | - |\n  | 2 |\n"
+	sets, err := ParseTables(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sets) != 2 || sets[0].Header[0] != "A" || sets[1].Rows[0][0] != "2" {
+		t.Fatalf("unexpected tables: %#v", sets)
+	}
+
+	if _, err := ParseTable("no tables here"); !errors.Is(err, ErrNoTable) {
+		t.Fatalf("expected ErrNoTable, got %v", err)
+	}
+}
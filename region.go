@@ -0,0 +1,240 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Managed regions are the parts of a hand-written document between
+//
+//	<!-- markdown:begin name -->
+//	<!-- markdown:end name -->
+//
+// markers, whose content is generated by a builder. Markers are only read on
+// lines of their own, outside fenced code blocks, so documents can show the
+// syntax in examples.
+var (
+	regionBegin = regexp.MustCompile(`^\s*<!--\s*markdown:begin\s+(\S+)\s*-->\s*$`)
+	regionEnd   = regexp.MustCompile(`^\s*<!--\s*markdown:end\s+(\S+)\s*-->\s*$`)
+	// regionInline is a region opened and closed on one line, which has no
+	// line to hold content.
+	regionInline = regexp.MustCompile(`^\s*<!--\s*markdown:begin\s+(\S+)\s*-->\s*<!--\s*markdown:end\s+(\S+)\s*-->\s*$`)
+)
+
+// region is the content of a managed region in source: the lines after its
+// begin marker up to the line of its end marker.
+type region struct {
+	name       string
+	start, end int
+	lineFeed   string
+}
+
+func findRegions(src []byte) ([]region, error) {
+	var regions []region
+	var open *region
+	var fence []byte
+	for offset, number := 0, 1; offset < len(src); number++ {
+		lineStart := offset
+		line := src[offset:]
+		if newline := bytes.IndexByte(line, '\n'); newline >= 0 {
+			line = line[:newline+1]
+		}
+		offset += len(line)
+
+		if fence != nil {
+			if closesFence(line, fence) {
+				fence = nil
+			}
+			continue
+		}
+		if marker := openingFence(line); marker != nil {
+			fence = marker
+			continue
+		}
+
+		if open != nil {
+			if m := regionEnd.FindSubmatch(line); m != nil && string(m[1]) == open.name {
+				open.end = lineStart
+				regions = append(regions, *open)
+				open = nil
+			}
+			continue
+		}
+		if m := regionInline.FindSubmatch(line); m != nil && bytes.Equal(m[1], m[2]) {
+			return nil, fmt.Errorf("%w: line %d: region %q", ErrInvalidRegionMarker, number, m[1])
+		}
+		if m := regionBegin.FindSubmatch(line); m != nil {
+			open = &region{name: string(m[1]), start: offset, lineFeed: "\n"}
+			if bytes.HasSuffix(line, []byte("\r\n")) {
+				open.lineFeed = "\r\n"
+			}
+		}
+	}
+	if open != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnterminatedRegion, open.name)
+	}
+	return regions, nil
+}
+
+// openingFence returns the fence of a line opening a fenced code block, such
+// as "```" or "~~~~", or nil.
+func openingFence(line []byte) []byte {
+	trimmed := trimFenceIndent(line)
+	if len(trimmed) < 3 || (trimmed[0] != '`' && trimmed[0] != '~') {
+		return nil
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == trimmed[0] {
+		n++
+	}
+	if n < 3 || (trimmed[0] == '`' && bytes.IndexByte(trimmed[n:], '`') >= 0) {
+		return nil
+	}
+	return trimmed[:n]
+}
+
+// closesFence reports whether line closes a fenced code block opened with
+// fence.
+func closesFence(line, fence []byte) bool {
+	trimmed := bytes.TrimRight(trimFenceIndent(line), " \t\r\n")
+	return len(trimmed) >= len(fence) && trimmed[0] == fence[0] && len(bytes.Trim(trimmed, string(fence[:1]))) == 0
+}
+
+// trimFenceIndent removes the up to three spaces a fence can be indented by;
+// further indentation makes the line indented code.
+func trimFenceIndent(line []byte) []byte {
+	for i := 0; i < 3 && len(line) > 0 && line[0] == ' '; i++ {
+		line = line[1:]
+	}
+	return line
+}
+
+// regionContent renders a builder as the content of a region, ending with
+// one line feed of the document.
+func regionContent(m *Markdown, lineFeed string) ([]byte, error) {
+	if err := m.Error(); err != nil {
+		return nil, err
+	}
+	out, err := m.renderMarkdown()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render markdown text: %w", err)
+	}
+	out = strings.TrimRight(strings.ReplaceAll(out, "\r\n", "\n"), "\n")
+	if out == "" {
+		return nil, nil
+	}
+	return []byte(strings.ReplaceAll(out, "\n", lineFeed) + lineFeed), nil
+}
+
+// UpdateRegions returns src with the content of each managed region named in
+// regions replaced by the output of its builder. Everything outside the
+// regions, including the markers, is kept byte for byte. Regions of src not
+// named in regions are left alone; a name without a region in src is an
+// ErrRegionNotFound error.
+func UpdateRegions(src []byte, regions map[string]*Markdown) ([]byte, error) {
+	updated, _, err := updateRegions(src, regions)
+	return updated, err
+}
+
+// CheckRegions reports whether the managed regions of src hold the output of
+// their builders, returning a *RegionDriftError naming the regions that
+// don't.
+func CheckRegions(src []byte, regions map[string]*Markdown) error {
+	_, stale, err := updateRegions(src, regions)
+	if err != nil {
+		return err
+	}
+	if len(stale) > 0 {
+		return &RegionDriftError{Regions: stale}
+	}
+	return nil
+}
+
+func updateRegions(src []byte, regions map[string]*Markdown) ([]byte, []string, error) {
+	found, err := findRegions(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	var missing []string
+	for name := range regions {
+		if !containsRegion(found, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, nil, fmt.Errorf("%w: %s", ErrRegionNotFound, strings.Join(missing, ", "))
+	}
+
+	var buf bytes.Buffer
+	var stale []string
+	last := 0
+	for _, r := range found {
+		m, ok := regions[r.name]
+		if !ok {
+			continue
+		}
+		content, err := regionContent(m, r.lineFeed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("region %q: %w", r.name, err)
+		}
+		if !bytes.Equal(src[r.start:r.end], content) {
+			stale = append(stale, r.name)
+		}
+		buf.Write(src[last:r.start])
+		buf.Write(content)
+		last = r.end
+	}
+	buf.Write(src[last:])
+	return buf.Bytes(), stale, nil
+}
+
+func containsRegion(regions []region, name string) bool {
+	for _, r := range regions {
+		if r.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateFile rewrites the managed regions of the file at path with
+// UpdateRegions. The file is only written when a region changed.
+func UpdateFile(path string, regions map[string]*Markdown) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read markdown: %w", err)
+	}
+	updated, stale, err := updateRegions(src, regions)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+	if err := os.WriteFile(path, updated, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+	return nil
+}
+
+// CheckFile reports drift in the managed regions of the file at path with
+// CheckRegions, without writing it.
+func CheckFile(path string, regions map[string]*Markdown) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read markdown: %w", err)
+	}
+	if err := CheckRegions(src, regions); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package markdown
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func flagTable() *Markdown {
+	return NewMarkdown(nil, WithLineEnding("\n")).Table(TableSet{
+		Header: []string{"Flag", "Default"},
+		Rows:   [][]string{{"-v", "false"}},
+	})
+}
+
+func TestUpdateRegions(t *testing.T) {
+	t.Parallel()
+
+	src := strings.Join([]string{
+		"# Tool",
+		"",
+		"Hand-written  text, kept as is.",
+		"<!-- markdown:begin flags -->",
+		"stale",
+		"<!-- markdown:end flags -->",
+		"",
+		"<!--markdown:begin other-->",
+		"untouched",
+		"<!--markdown:end other-->",
+		"",
+	}, "\n")
+	updated, err := UpdateRegions([]byte(src), map[string]*Markdown{"flags": flagTable()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := strings.Join([]string{
+		"# Tool",
+		"",
+		"Hand-written  text, kept as is.",
+		"<!-- markdown:begin flags -->",
+		"| Flag | Default |",
+		"| ---- | ------- |",
+		"| -v   | false   |",
+		"<!-- markdown:end flags -->",
+		"",
+		"<!--markdown:begin other-->",
+		"untouched",
+		"<!--markdown:end other-->",
+		"",
+	}, "\n")
+	if string(updated) != want {
+		t.Fatalf("unexpected document\nwant: %q\ngot:  %q", want, updated)
+	}
+
+	if err := CheckRegions([]byte(want), map[string]*Markdown{"flags": flagTable()}); err != nil {
+		t.Fatalf("unexpected drift: %v", err)
+	}
+	err = CheckRegions([]byte(src), map[string]*Markdown{"flags": flagTable(), "other": NewMarkdown(nil)})
+	var drift *RegionDriftError
+	if !errors.As(err, &drift) || !errors.Is(err, ErrRegionDrift) {
+		t.Fatalf("expected a RegionDriftError, got %v", err)
+	}
+	if !reflect.DeepEqual(drift.Regions, []string{"flags", "other"}) {
+		t.Fatalf("unexpected stale regions: %q", drift.Regions)
+	}
+}
+
+func TestUpdateRegionsLineEndings(t *testing.T) {
+	t.Parallel()
+
+	src := "intro\r\n<!-- markdown:begin flags -->\r\n<!-- markdown:end flags -->\r\n"
+	updated, err := UpdateRegions([]byte(src), map[string]*Markdown{"flags": flagTable()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "intro\r\n<!-- markdown:begin flags -->\r\n| Flag | Default |\r\n| ---- | ------- |\r\n| -v   | false   |\r\n<!-- markdown:end flags -->\r\n"
+	if string(updated) != want {
+		t.Fatalf("unexpected document\nwant: %q\ngot:  %q", want, updated)
+	}
+}
+
+func TestUpdateRegionsCodeFences(t *testing.T) {
+	t.Parallel()
+
+	// Fences indented by four or more columns are indented code, which
+	// neither opens nor closes a fenced block.
+	example := strings.Join([]string{
+		"    ```",
+		"",
+		"````markdown",
+		"    ````",
+		"<!-- markdown:begin flags -->",
+		"```",
+		"<!-- markdown:end flags -->",
+		"   ````",
+		"",
+	}, "\n")
+	src := example + "<!-- markdown:begin flags -->\n" +
+		"~~~\n<!-- markdown:end flags -->\n~~~\n" +
+		"<!-- markdown:end flags -->\n"
+	updated, err := UpdateRegions([]byte(src), map[string]*Markdown{"flags": flagTable()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := example + "<!-- markdown:begin flags -->\n" +
+		"| Flag | Default |\n| ---- | ------- |\n| -v   | false   |\n" +
+		"<!-- markdown:end flags -->\n"
+	if string(updated) != want {
+		t.Fatalf("unexpected document\nwant: %q\ngot:  %q", want, updated)
+	}
+}
+
+func TestUpdateRegionsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want error
+	}{
+		{name: "missing", src: "no regions\n", want: ErrRegionNotFound},
+		{name: "unterminated", src: "<!-- markdown:begin flags -->\nbody\n<!-- markdown:end other -->\n", want: ErrUnterminatedRegion},
+		{name: "one line", src: "<!-- markdown:begin flags --><!-- markdown:end flags -->\n", want: ErrInvalidRegionMarker},
+		{name: "marker in text", src: "See `<!-- markdown:begin flags -->`.\n", want: ErrRegionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := UpdateRegions([]byte(tt.src), map[string]*Markdown{"flags": flagTable()})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	invalid := NewMarkdown(nil).Table(TableSet{Header: []string{"A"}, Rows: [][]string{{"1", "2"}}})
+	_, err := UpdateRegions([]byte("<!-- markdown:begin t -->\n<!-- markdown:end t -->\n"), map[string]*Markdown{"t": invalid})
+	if !errors.Is(err, ErrMismatchColumn) {
+		t.Fatalf("expected ErrMismatchColumn, got %v", err)
+	}
+}
+
+func TestUpdateFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "README.md")
+	src := "<!-- markdown:begin flags -->\n<!-- markdown:end flags -->\n"
+	if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	regions := map[string]*Markdown{"flags": flagTable()}
+	if err := CheckFile(path, regions); !errors.Is(err, ErrRegionDrift) {
+		t.Fatalf("expected ErrRegionDrift, got %v", err)
+	}
+	if err := UpdateFile(path, regions); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := CheckFile(path, regions); err != nil {
+		t.Fatalf("unexpected drift after update: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("unexpected mode: %v", info.Mode())
+	}
+}
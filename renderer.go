@@ -1,6 +1,7 @@
 package markdown
 
 import (
+	"bytes"
 	"fmt"
 	"runtime"
 	"strings"
@@ -8,147 +9,537 @@ import (
 
 	"github.com/yuin/goldmark/ast"
 	tableast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/util"
 )
 
-func (m *Markdown) renderMarkdown() string {
-	lines := collectDocumentLines(m.doc)
-	return strings.Join(lines, lineFeed())
+// markdownRenderer turns the document AST back into Markdown text. Nodes
+// produced by the goldmark parser reference source, while builder nodes
+// carry their own values.
+type markdownRenderer struct {
+	options
+	source    []byte
+	inCell    bool
+	inHeading bool
+	inSetext  bool
+	degraded  []string
 }
 
-func collectDocumentLines(doc *ast.Document) []string {
+func (m *Markdown) newRenderer() *markdownRenderer {
+	return &markdownRenderer{options: m.options, source: m.source}
+}
+
+// renderMarkdown renders the document. The error reports constructs written
+// with a fallback syntax when the flavor is strict.
+func (m *Markdown) renderMarkdown() (string, error) {
+	m.resolveTableOfContents()
+	r := m.newRenderer()
+	lines := r.collectDocumentLines(m.doc)
+	lf := r.lineFeed()
+	if r.compactBlocks || len(lines) == 0 {
+		return strings.Join(lines, lf), r.flavorError()
+	}
+	return strings.Join(lines, lf) + lf, r.flavorError()
+}
+
+func (r *markdownRenderer) collectDocumentLines(doc *ast.Document) []string {
+	return r.collectBlockLines(doc, !r.compactBlocks)
+}
+
+// collectBlockLines renders the block children of node. When separate is set
+// blocks are divided by a blank line, as CommonMark requires for consecutive
+// paragraphs, and blocks that render to blank lines only are dropped.
+func (r *markdownRenderer) collectBlockLines(node ast.Node, separate bool) []string {
 	var lines []string
-	for node := doc.FirstChild(); node != nil; node = node.NextSibling() {
-		lines = append(lines, renderNodeLines(node, 0)...)
+	var prev ast.Node
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		childLines := r.renderNodeLines(child)
+		if !separate {
+			lines = append(lines, childLines...)
+			continue
+		}
+		childLines = trimTrailingBlankLines(childLines)
+		if len(childLines) == 0 {
+			continue
+		}
+		if prev != nil {
+			lines = append(lines, "")
+			if r.adjacentListsMerge(prev, child) {
+				// Two lists with the same marker separated only by a blank
+				// line would be parsed as one loose list.
+				lines = append(lines, "<!-- -->", "")
+			}
+		}
+		lines = append(lines, childLines...)
+		prev = child
 	}
 	return lines
 }
 
-func renderNodeLines(node ast.Node, indentLevel int) []string {
+func trimTrailingBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func (r *markdownRenderer) adjacentListsMerge(prev, next ast.Node) bool {
+	a, ok := prev.(*ast.List)
+	if !ok {
+		return false
+	}
+	b, ok := next.(*ast.List)
+	return ok && r.listMarker(a) == r.listMarker(b)
+}
+
+func (r *markdownRenderer) renderNodeLines(node ast.Node) []string {
 	switch n := node.(type) {
 	case *ast.Heading:
-		return []string{renderHeadingLine(n)}
-	case *ast.Paragraph:
-		return []string{collectInlineText(n)}
+		return r.renderHeadingLines(n)
+	case *ast.Paragraph, *ast.TextBlock:
+		return splitLines(r.collectInlineText(n))
 	case *ast.Blockquote:
-		return renderBlockquoteLines(n)
+		return r.renderQuotedLines(n)
+	case *calloutBlock:
+		return r.renderCalloutLines(n)
+	case *detailsBlock:
+		return r.renderDetailsLines(n)
+	case *tocBlock:
+		return r.collectBlockLines(n, !r.compactBlocks)
+	case *frontMatterBlock:
+		return r.renderFrontMatterLines(n)
 	case *ast.List:
-		return renderListLines(n, indentLevel)
+		return r.renderListLines(n)
 	case *ast.ThematicBreak:
 		return []string{"---"}
 	case *literalBlock:
-		return []string{n.value}
+		return splitLines(n.value)
 	case *codeBlockNode:
-		return renderCodeBlockLines(n)
+		return renderFencedLines(r.fence(), string(n.language), n.value)
+	case *ast.FencedCodeBlock:
+		info := ""
+		if n.Info != nil {
+			info = string(n.Info.Segment.Value(r.source))
+		}
+		return renderFencedLines(r.fence(), info, r.collectBlockText(n))
+	case *ast.CodeBlock:
+		return r.renderIndentedCodeLines(n)
+	case *ast.HTMLBlock:
+		return r.renderHTMLBlockLines(n)
 	case *tableast.Table:
-		return renderTableLines(n)
+		return r.renderTableLines(n)
 	default:
 		return nil
 	}
 }
 
-func renderHeadingLine(h *ast.Heading) string {
+func (r *markdownRenderer) renderHeadingLines(h *ast.Heading) []string {
+	setext := r.headingStyle == HeadingStyleSetext && h.Level <= 2 && h.HasChildren()
+	r.inHeading = true
+	r.inSetext = setext
+	content := r.collectInlineText(h)
+	r.inHeading = false
+	r.inSetext = false
+	if setext && strings.TrimSpace(content) != "" {
+		underline := "="
+		if h.Level == 2 {
+			underline = "-"
+		}
+		width := displayWidth(content)
+		if width < 3 {
+			width = 3
+		}
+		return []string{content, strings.Repeat(underline, width)}
+	}
 	prefix := strings.Repeat("#", h.Level)
-	content := collectInlineText(h)
 	if content == "" {
-		return prefix
+		return []string{prefix}
+	}
+	return []string{fmt.Sprintf("%s %s", prefix, content)}
+}
+
+func (r *markdownRenderer) collectInlineText(node ast.Node) string {
+	var buf strings.Builder
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		r.writeInline(&buf, child)
+	}
+	return buf.String()
+}
+
+func (r *markdownRenderer) writeInline(buf *strings.Builder, node ast.Node) {
+	switch c := node.(type) {
+	case *ast.String:
+		if c.IsRaw() || c.IsCode() || r.rawText {
+			buf.Write(c.Value)
+		} else {
+			buf.WriteString(escapeText(string(c.Value), r.escapeContext(c), r.startsLine(c)))
+		}
+	case *ast.Text:
+		buf.Write(c.Segment.Value(r.source))
+		// Line breaks are normalized to the configured line ending when the
+		// block lines are joined.
+		if c.HardLineBreak() {
+			buf.WriteString("  \n")
+		} else if c.SoftLineBreak() {
+			buf.WriteString("\n")
+		}
+	case *ast.Emphasis:
+		marker := strings.Repeat(r.emphasisDelimiter(buf.String(), c), c.Level)
+		buf.WriteString(marker)
+		buf.WriteString(r.collectInlineText(c))
+		buf.WriteString(marker)
+	case *tableast.Strikethrough:
+		buf.WriteString("~~")
+		buf.WriteString(r.collectInlineText(c))
+		buf.WriteString("~~")
+	case *highlightNode:
+		open, closing := r.highlightDelimiters()
+		buf.WriteString(open)
+		buf.WriteString(r.collectInlineText(c))
+		buf.WriteString(closing)
+	case *ast.CodeSpan:
+		content := r.collectInlineText(c)
+		if r.inCell {
+			// goldmark drops the backslash of escaped pipes inside code spans in cells.
+			content = strings.ReplaceAll(content, "|", `\|`)
+		}
+		buf.WriteString(renderCodeSpan(content))
+	case *ast.Link:
+		buf.WriteString("[")
+		buf.WriteString(r.collectInlineText(c))
+		buf.WriteString("]")
+		buf.WriteString(renderLinkTarget(c.Destination, c.Title))
+	case *ast.Image:
+		buf.WriteString("![")
+		buf.WriteString(r.collectInlineText(c))
+		buf.WriteString("]")
+		buf.WriteString(renderLinkTarget(c.Destination, c.Title))
+	case *ast.AutoLink:
+		buf.WriteString("<")
+		buf.Write(c.Label(r.source))
+		buf.WriteString(">")
+	case *ast.RawHTML:
+		buf.WriteString(r.rawHTML(c))
+	case *lineBreakNode:
+		switch {
+		case r.inCell:
+			buf.WriteString("<br>")
+		case r.inHeading:
+			buf.WriteString(" ")
+		default:
+			buf.WriteString("  \n")
+		}
+	case *tableast.TaskCheckBox:
+		if r.inCell {
+			// Task list markers are only recognized in list items.
+			buf.WriteString(cellCheckBox(c.IsChecked))
+			if c.NextSibling() != nil {
+				buf.WriteString(" ")
+			}
+		} else if c.IsChecked {
+			buf.WriteString("[x] ")
+		} else {
+			buf.WriteString("[ ] ")
+		}
+	}
+}
+
+func cellCheckBox(checked bool) string {
+	if checked {
+		return "\u2611"
+	}
+	return "\u2610"
+}
+
+func (r *markdownRenderer) escapeContext(node ast.Node) escapeContext {
+	switch {
+	case r.inCell:
+		return escapeTableCell
+	case r.inHeading:
+		return escapeHeading
+	}
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch parent.(type) {
+		case *ast.Link, *ast.Image:
+			return escapeLinkText
+		}
+	}
+	return escapeParagraph
+}
+
+// emphasisDelimiter returns the delimiter for an emphasis node following the
+// inline text written so far. Underscores don't delimit emphasis inside words, so asterisks are
+// used there regardless of the configured style.
+func (r *markdownRenderer) emphasisDelimiter(written string, node ast.Node) string {
+	if r.emphasisStyle != EmphasisStyleUnderscore {
+		return "*"
+	}
+	if last, _ := utf8.DecodeLastRuneInString(written); isWordRune(last) {
+		return "*"
+	}
+	var next []byte
+	switch n := node.NextSibling().(type) {
+	case *ast.String:
+		next = n.Value
+	case *ast.Text:
+		next = n.Segment.Value(r.source)
+	}
+	if first, _ := utf8.DecodeRune(next); isWordRune(first) {
+		return "*"
+	}
+	return "_"
+}
+
+// startsLine reports whether an inline node is written at the start of a
+// line of its paragraph or setext heading.
+func (r *markdownRenderer) startsLine(node ast.Node) bool {
+	switch prev := node.PreviousSibling().(type) {
+	case nil:
+		switch node.Parent().(type) {
+		case *ast.Paragraph, *ast.TextBlock:
+			return true
+		case *ast.Heading:
+			return r.inSetext
+		}
+		return false
+	case *ast.String:
+		return len(prev.Value) > 0 && prev.Value[len(prev.Value)-1] == '\n'
+	case *ast.Text:
+		return prev.SoftLineBreak() || prev.HardLineBreak()
+	default:
+		return false
 	}
-	return fmt.Sprintf("%s %s", prefix, content)
 }
 
-func collectInlineText(node ast.Node) string {
+// collectPlainText returns the text content of inline nodes without
+// formatting or escapes, as used for TOC entries and heading anchors.
+func (r *markdownRenderer) collectPlainText(node ast.Node) string {
 	var buf strings.Builder
 	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
 		switch c := child.(type) {
 		case *ast.String:
 			buf.Write(c.Value)
+		case *ast.Text:
+			value := c.Segment.Value(r.source)
+			if _, code := node.(*ast.CodeSpan); !code {
+				value = unescapeText(value)
+			}
+			buf.Write(value)
+			if c.SoftLineBreak() || c.HardLineBreak() {
+				buf.WriteString(" ")
+			}
+		case *ast.AutoLink:
+			buf.Write(c.Label(r.source))
+		case *ast.RawHTML:
+			// Table cells spell line breaks as <br>.
+			if r.inCell && isLineBreakTag(r.rawHTML(c)) {
+				buf.WriteString("\n")
+			}
+		case *lineBreakNode:
+			if r.inCell {
+				buf.WriteString("\n")
+			} else {
+				buf.WriteString(" ")
+			}
+		default:
+			buf.WriteString(r.collectPlainText(c))
 		}
 	}
 	return buf.String()
 }
 
-func renderBlockquoteLines(bq *ast.Blockquote) []string {
+func (r *markdownRenderer) rawHTML(node *ast.RawHTML) string {
+	var buf strings.Builder
+	for i := 0; i < node.Segments.Len(); i++ {
+		segment := node.Segments.At(i)
+		buf.Write(segment.Value(r.source))
+	}
+	return buf.String()
+}
+
+func isLineBreakTag(tag string) bool {
+	switch strings.ToLower(strings.Join(strings.Fields(tag), "")) {
+	case "<br>", "<br/>":
+		return true
+	}
+	return false
+}
+
+// unescapeText resolves backslash escapes and entity references in source
+// text the way goldmark does when rendering it.
+func unescapeText(value []byte) []byte {
+	var buf bytes.Buffer
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '\\' && i+1 < len(value) && util.IsPunct(value[i+1]) {
+			buf.WriteByte(value[i+1])
+			i++
+			continue
+		}
+		if c == '&' {
+			if end := bytes.IndexByte(value[i:], ';'); end > 0 {
+				ref := value[i : i+end+1]
+				if resolved := util.ResolveNumericReferences(util.ResolveEntityNames(ref)); !bytes.Equal(resolved, ref) {
+					buf.Write(resolved)
+					i += end
+					continue
+				}
+			}
+		}
+		buf.WriteByte(c)
+	}
+	return buf.Bytes()
+}
+
+func renderCodeSpan(content string) string {
+	fence := "`"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(content, "`") || strings.HasSuffix(content, "`") ||
+		(strings.HasPrefix(content, " ") && strings.HasSuffix(content, " ") && strings.TrimSpace(content) != "") {
+		content = " " + content + " "
+	}
+	return fence + content + fence
+}
+
+// renderLinkTarget writes a destination and title held in source form, as
+// goldmark stores them.
+func renderLinkTarget(destination, title []byte) string {
+	dest := string(destination)
+	if dest == "" || strings.Contains(dest, " ") {
+		dest = "<" + dest + ">"
+	}
+	if len(title) == 0 {
+		return "(" + dest + ")"
+	}
+	return fmt.Sprintf("(%s \"%s\")", dest, quoteLinkTitle(string(title)))
+}
+
+func quoteLinkTitle(title string) string {
+	var buf strings.Builder
+	escaped := false
+	for _, r := range title {
+		if r == '"' && !escaped {
+			buf.WriteRune('\\')
+		}
+		escaped = r == '\\' && !escaped
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+func (r *markdownRenderer) renderQuotedLines(node ast.Node) []string {
 	var lines []string
-	for child := bq.FirstChild(); child != nil; child = child.NextSibling() {
-		childLines := renderNodeLines(child, 0)
+	if !r.compactBlocks {
+		for _, line := range r.collectBlockLines(node, true) {
+			lines = append(lines, quoteLine(line))
+		}
+		return lines
+	}
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		childLines := r.renderNodeLines(child)
 		if len(childLines) == 0 {
 			lines = append(lines, ">")
 			continue
 		}
 		for _, line := range childLines {
-			if line == "" {
-				lines = append(lines, ">")
-				continue
-			}
-			lines = append(lines, "> "+line)
+			lines = append(lines, quoteLine(line))
 		}
 	}
 	return lines
 }
 
-func renderListLines(list *ast.List, indentLevel int) []string {
+func quoteLine(line string) string {
+	if line == "" {
+		return ">"
+	}
+	return "> " + line
+}
+
+func (r *markdownRenderer) renderListLines(list *ast.List) []string {
 	var lines []string
-	ordered := list.IsOrdered()
+	loose := !r.compactBlocks && !list.IsTight
+	// Lists built here start at 1 unless set otherwise, and parsed lists
+	// keep their number, which CommonMark allows to be 0.
 	counter := list.Start
-	if !ordered {
-		counter = 1
-	} else if counter == 0 {
-		counter = 1
-	}
 	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
 		li, ok := item.(*ast.ListItem)
 		if !ok {
 			continue
 		}
-		primary := ""
-		var nested []ast.Node
-		for child := li.FirstChild(); child != nil; child = child.NextSibling() {
-			switch c := child.(type) {
-			case *ast.Paragraph:
-				if primary == "" {
-					primary = collectInlineText(c)
-				} else {
-					primary += lineFeed() + collectInlineText(c)
-				}
-			case *ast.List:
-				nested = append(nested, c)
-			case *literalBlock:
-				if primary == "" {
-					primary = c.value
-				} else {
-					primary += lineFeed() + c.value
-				}
-			}
+		marker := r.itemMarker(list, counter)
+		// Only the first number matters, but every marker must stay within
+		// the nine digits CommonMark allows.
+		counter = min(counter+1, maxListStart)
+
+		if loose && len(lines) > 0 {
+			lines = append(lines, "")
 		}
-		if primary == "" {
-			primary = ""
+		body := r.collectBlockLines(li, loose)
+		if !r.compactBlocks {
+			body = trimTrailingBlankLines(body)
 		}
-		indent := strings.Repeat("  ", indentLevel)
-		if ordered {
-			lines = append(lines, fmt.Sprintf("%s%d. %s", indent, counter, primary))
-			counter++
-		} else {
-			lines = append(lines, fmt.Sprintf("%s- %s", indent, primary))
+		if len(body) == 0 {
+			lines = append(lines, marker)
+			continue
 		}
-		for _, nestedList := range nested {
-			lines = append(lines, renderListLines(nestedList.(*ast.List), indentLevel+1)...)
+		lines = append(lines, marker+body[0])
+		indent := strings.Repeat(" ", utf8.RuneCountInString(marker))
+		for _, line := range body[1:] {
+			if line == "" {
+				lines = append(lines, "")
+				continue
+			}
+			lines = append(lines, indent+line)
 		}
 	}
 	return lines
 }
 
-func renderCodeBlockLines(cb *codeBlockNode) []string {
-	lf := lineFeed()
+func (r *markdownRenderer) itemMarker(list *ast.List, number int) string {
+	if list.IsOrdered() {
+		return fmt.Sprintf("%d%c ", number, list.Marker)
+	}
+	return string(r.listMarker(list)) + " "
+}
+
+func renderFencedLines(fence, info, value string) []string {
+	for strings.Contains(value, fence) {
+		fence += fence[:1]
+	}
+	lines := []string{fence + info}
+	lines = append(lines, splitLines(value)...)
+	return append(lines, fence)
+}
+
+func (r *markdownRenderer) renderIndentedCodeLines(cb *ast.CodeBlock) []string {
+	var lines []string
+	for _, line := range splitLines(r.collectBlockText(cb)) {
+		lines = append(lines, "    "+line)
+	}
+	return lines
+}
+
+func (r *markdownRenderer) renderHTMLBlockLines(block *ast.HTMLBlock) []string {
+	lines := splitLines(r.collectBlockText(block))
+	if block.HasClosure() {
+		lines = append(lines, strings.TrimRight(string(block.ClosureLine.Value(r.source)), "\r\n"))
+	}
+	return lines
+}
+
+// collectBlockText returns the raw source lines of a parsed block.
+func (r *markdownRenderer) collectBlockText(node ast.Node) string {
 	var buf strings.Builder
-	buf.WriteString("```")
-	buf.WriteString(string(cb.language))
-	buf.WriteString(lf)
-	buf.WriteString(cb.value)
-	buf.WriteString(lf)
-	buf.WriteString("```")
-	return []string{buf.String()}
+	segments := node.Lines()
+	for i := 0; i < segments.Len(); i++ {
+		segment := segments.At(i)
+		buf.Write(segment.Value(r.source))
+	}
+	return strings.TrimRight(buf.String(), "\r\n")
 }
 
-func renderTableLines(table *tableast.Table) []string {
+func (r *markdownRenderer) renderTableLines(table *tableast.Table) []string {
 	var headerCells []string
 	var header *tableast.TableHeader
 	if h, ok := table.FirstChild().(*tableast.TableHeader); ok {
@@ -160,7 +551,7 @@ func renderTableLines(table *tableast.Table) []string {
 			if !ok {
 				continue
 			}
-			headerCells = append(headerCells, collectCellText(c))
+			headerCells = append(headerCells, r.collectCellText(c))
 		}
 	}
 
@@ -170,66 +561,86 @@ func renderTableLines(table *tableast.Table) []string {
 		if !ok {
 			continue
 		}
-		bodyRows = append(bodyRows, collectRowTexts(row))
+		bodyRows = append(bodyRows, r.collectRowTexts(row))
 	}
 
-	widths := computeColumnWidths(headerCells, bodyRows)
+	widths := r.tablePadding(computeColumnWidths(headerCells, bodyRows))
 	alignments := normalizeAlignments(table.Alignments, len(widths))
 
-	lf := lineFeed()
-	var buf strings.Builder
-
+	var lines []string
 	if len(headerCells) > 0 {
-		buf.WriteString("|")
-		for i, cell := range headerCells {
-			buf.WriteString(" ")
-			buf.WriteString(padCell(cell, widths[i], alignments[i]))
-			buf.WriteString(" |")
-		}
-		buf.WriteString(lf)
+		lines = append(lines, formatTableRow(headerCells, widths, alignments), formatDelimiterRow(widths, alignments))
+	}
+	for _, row := range bodyRows {
+		lines = append(lines, formatTableRow(row, widths, alignments))
+	}
+	return append(lines, "")
+}
 
-		buf.WriteString("|")
-		for i := range widths {
-			buf.WriteString(" ")
-			buf.WriteString(alignmentSegment(alignments[i], widths[i]))
-			buf.WriteString(" |")
+// tablePadding returns the widths cells are padded to.
+func (o options) tablePadding(widths []int) []int {
+	for i := range widths {
+		switch {
+		case o.noTablePadding:
+			widths[i] = 0
+		case widths[i] < 3:
+			// Delimiter cells are at least three columns wide; pad the
+			// other cells to match.
+			widths[i] = 3
 		}
-		buf.WriteString(lf)
 	}
+	return widths
+}
 
-	for _, row := range bodyRows {
-		buf.WriteString("|")
-		for i, cellText := range row {
-			buf.WriteString(" ")
-			buf.WriteString(padCell(cellText, widths[i], alignments[i]))
-			buf.WriteString(" |")
+// formatTableRow writes rendered cells as a table line. Cells beyond the
+// widths are dropped.
+func formatTableRow(cells []string, widths []int, alignments []tableast.Alignment) string {
+	var buf strings.Builder
+	buf.WriteString("|")
+	for i, cell := range cells {
+		if i >= len(widths) {
+			break
 		}
-		buf.WriteString(lf)
+		buf.WriteString(" ")
+		buf.WriteString(padCell(cell, widths[i], alignments[i]))
+		buf.WriteString(" |")
 	}
+	return buf.String()
+}
 
-	return []string{buf.String()}
+func formatDelimiterRow(widths []int, alignments []tableast.Alignment) string {
+	var buf strings.Builder
+	buf.WriteString("|")
+	for i := range widths {
+		buf.WriteString(" ")
+		buf.WriteString(alignmentSegment(alignments[i], widths[i]))
+		buf.WriteString(" |")
+	}
+	return buf.String()
 }
 
-func collectCellText(cell *tableast.TableCell) string {
+func (r *markdownRenderer) collectCellText(cell *tableast.TableCell) string {
+	r.inCell = true
+	defer func() { r.inCell = false }()
 	var buf strings.Builder
 	for child := cell.FirstChild(); child != nil; child = child.NextSibling() {
 		switch c := child.(type) {
 		case *ast.Paragraph:
-			buf.WriteString(collectInlineText(c))
+			buf.WriteString(r.collectInlineText(c))
 		case *literalBlock:
 			buf.WriteString(c.value)
-		case *ast.String:
-			buf.Write(c.Value)
+		default:
+			r.writeInline(&buf, c)
 		}
 	}
 	return buf.String()
 }
 
-func collectRowTexts(row *tableast.TableRow) []string {
+func (r *markdownRenderer) collectRowTexts(row *tableast.TableRow) []string {
 	var cells []string
 	for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
 		if c, ok := cell.(*tableast.TableCell); ok {
-			cells = append(cells, collectCellText(c))
+			cells = append(cells, r.collectCellText(c))
 		}
 	}
 	return cells
@@ -238,14 +649,14 @@ func collectRowTexts(row *tableast.TableRow) []string {
 func computeColumnWidths(header []string, rows [][]string) []int {
 	widths := make([]int, len(header))
 	for i, cell := range header {
-		widths[i] = runeWidth(cell)
+		widths[i] = cellWidth(cell)
 	}
 	for _, row := range rows {
 		for i, cell := range row {
 			if i >= len(widths) {
 				continue
 			}
-			if w := runeWidth(cell); w > widths[i] {
+			if w := cellWidth(cell); w > widths[i] {
 				widths[i] = w
 			}
 		}
@@ -253,6 +664,18 @@ func computeColumnWidths(header []string, rows [][]string) []int {
 	return widths
 }
 
+// cellWidth returns the width of the widest line of a cell whose lines are
+// separated by <br>, so one wrapped cell doesn't widen the whole column.
+func cellWidth(cell string) int {
+	width := 0
+	for _, line := range strings.Split(cell, "<br>") {
+		if w := displayWidth(line); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
 func lineFeed() string {
 	if runtime.GOOS == "windows" {
 		return "\r\n"
@@ -260,12 +683,12 @@ func lineFeed() string {
 	return "\n"
 }
 
-func runeWidth(value string) int {
-	return utf8.RuneCountInString(value)
+func splitLines(value string) []string {
+	return strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n")
 }
 
 func padCell(value string, width int, align tableast.Alignment) string {
-	padding := width - runeWidth(value)
+	padding := width - displayWidth(value)
 	if padding <= 0 {
 		return value
 	}
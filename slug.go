@@ -0,0 +1,188 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Slugger turns heading text into anchors. A Slugger is called once per
+// heading in document order and remembers the anchors it returned, so that
+// duplicate headings receive distinct anchors.
+type Slugger interface {
+	Slug(text string) string
+}
+
+// WithSlugger sets the constructor of the Slugger used for TOC links and
+// HTML heading ids. A new Slugger is created for every render. The default
+// is NewGitHubSlugger.
+func WithSlugger(newSlugger func() Slugger) Option {
+	return func(o *options) {
+		o.newSlugger = newSlugger
+	}
+}
+
+func (o options) slugger() Slugger {
+	if o.newSlugger != nil {
+		return o.newSlugger()
+	}
+	return NewGitHubSlugger()
+}
+
+// slugFunc adapts a slugify function and a duplicate policy to Slugger.
+type slugFunc struct {
+	slugify      func(string) string
+	disambiguate func(slug string, seen map[string]bool) string
+	seen         map[string]bool
+}
+
+func (s *slugFunc) Slug(text string) string {
+	slug := s.disambiguate(s.slugify(text), s.seen)
+	s.seen[slug] = true
+	return slug
+}
+
+// appendCounter resolves duplicates by appending -1, -2, and so on.
+func appendCounter(slug string, seen map[string]bool) string {
+	if !seen[slug] {
+		return slug
+	}
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", slug, i)
+		if !seen[candidate] {
+			return candidate
+		}
+	}
+}
+
+// NewGitHubSlugger returns a Slugger matching the anchors GitHub generates:
+// text is lowercased, characters other than letters, marks, numbers,
+// underscores, hyphens and spaces are removed, and spaces become hyphens.
+func NewGitHubSlugger() Slugger {
+	return &slugFunc{slugify: githubSlug, disambiguate: appendCounter, seen: map[string]bool{}}
+}
+
+func githubSlug(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ':
+			return '-'
+		case r == '-', unicode.IsLetter(r), unicode.IsMark(r), unicode.IsNumber(r), unicode.Is(unicode.Pc, r):
+			return r
+		default:
+			return -1
+		}
+	}, strings.ToLower(text))
+}
+
+var repeatedHyphens = regexp.MustCompile(`-{2,}`)
+
+// NewGitLabSlugger returns a Slugger matching GitLab: like GitHub, but runs
+// of hyphens are collapsed into one.
+func NewGitLabSlugger() Slugger {
+	return &slugFunc{slugify: gitlabSlug, disambiguate: appendCounter, seen: map[string]bool{}}
+}
+
+func gitlabSlug(text string) string {
+	slug := githubSlug(strings.TrimSpace(text))
+	return repeatedHyphens.ReplaceAllString(slug, "-")
+}
+
+// NewHugoSlugger returns a Slugger matching Hugo's default "github" anchor
+// style, which keeps letters, digits and underscores and turns spaces and
+// hyphens into hyphens.
+func NewHugoSlugger() Slugger {
+	return &slugFunc{slugify: hugoSlug, disambiguate: appendCounter, seen: map[string]bool{}}
+}
+
+func hugoSlug(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ', r == '-':
+			return '-'
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '_':
+			return unicode.ToLower(r)
+		default:
+			return -1
+		}
+	}, strings.TrimSpace(text))
+}
+
+// NewMkDocsSlugger returns a Slugger matching the toc extension of
+// Python-Markdown used by MkDocs: accented Latin letters are folded to ASCII,
+// other characters outside words are removed, and duplicates receive _1, _2
+// suffixes.
+func NewMkDocsSlugger() Slugger {
+	return &slugFunc{slugify: mkdocsSlug, disambiguate: appendUnderscoreCounter, seen: map[string]bool{}}
+}
+
+var mkdocsSeparators = regexp.MustCompile(`[-\s]+`)
+
+func mkdocsSlug(text string) string {
+	var buf strings.Builder
+	for _, r := range text {
+		if folded, ok := asciiFold[r]; ok {
+			buf.WriteString(folded)
+			continue
+		}
+		if r > unicode.MaxASCII {
+			continue
+		}
+		if r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			buf.WriteRune(r)
+		}
+	}
+	slug := strings.ToLower(strings.TrimSpace(buf.String()))
+	return mkdocsSeparators.ReplaceAllString(slug, "-")
+}
+
+var underscoreCounter = regexp.MustCompile(`^(.*)_([0-9]+)$`)
+
+// appendUnderscoreCounter resolves duplicates the way Python-Markdown does,
+// incrementing a trailing _N suffix.
+func appendUnderscoreCounter(slug string, seen map[string]bool) string {
+	for seen[slug] || slug == "" {
+		if m := underscoreCounter.FindStringSubmatch(slug); m != nil {
+			n, _ := strconv.Atoi(m[2])
+			slug = fmt.Sprintf("%s_%d", m[1], n+1)
+		} else {
+			slug += "_1"
+		}
+	}
+	return slug
+}
+
+// asciiFold approximates NFKD decomposition followed by dropping non-ASCII
+// characters for the Latin letters commonly found in headings.
+var asciiFold = func() map[rune]string {
+	groups := map[string]string{
+		"A": "ÀÁÂÃÄÅĀĂĄ", "a": "àáâãäåāăą",
+		"C": "ÇĆĈĊČ", "c": "çćĉċč",
+		"D": "Ď", "d": "ď",
+		"E": "ÈÉÊËĒĔĖĘĚ", "e": "èéêëēĕėęě",
+		"G": "ĜĞĠĢ", "g": "ĝğġģ",
+		"H": "Ĥ", "h": "ĥ",
+		"I": "ÌÍÎÏĨĪĬĮİ", "i": "ìíîïĩīĭį",
+		"J": "Ĵ", "j": "ĵ",
+		"K": "Ķ", "k": "ķ",
+		"L": "ĹĻĽ", "l": "ĺļľ",
+		"N": "ÑŃŅŇ", "n": "ñńņň",
+		"O": "ÒÓÔÕÖŌŎŐ", "o": "òóôõöōŏő",
+		"R": "ŔŖŘ", "r": "ŕŗř",
+		"S": "ŚŜŞŠ", "s": "śŝşš",
+		"T": "ŢŤ", "t": "ţť",
+		"U": "ÙÚÛÜŨŪŬŮŰŲ", "u": "ùúûüũūŭůűų",
+		"W": "Ŵ", "w": "ŵ",
+		"Y": "ÝŶŸ", "y": "ýÿŷ",
+		"Z": "ŹŻŽ", "z": "źżž",
+	}
+	fold := map[rune]string{}
+	for ascii, letters := range groups {
+		for _, r := range letters {
+			fold[r] = ascii
+		}
+	}
+	return fold
+}()
@@ -0,0 +1,116 @@
+package markdown
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSluggers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		slugger func() Slugger
+		texts   []string
+		want    []string
+	}{
+		{
+			name:    "github",
+			slugger: NewGitHubSlugger,
+			texts:   []string{"API (v2)", "Überblick", "Usage", "Usage", "Usage", "snake_case & more", "日本語 見出し"},
+			want:    []string{"api-v2", "überblick", "usage", "usage-1", "usage-2", "snake_case--more", "日本語-見出し"},
+		},
+		{
+			name:    "github numbered suffix collision",
+			slugger: NewGitHubSlugger,
+			texts:   []string{"Foo 1", "Foo", "Foo"},
+			want:    []string{"foo-1", "foo", "foo-2"},
+		},
+		{
+			name:    "gitlab",
+			slugger: NewGitLabSlugger,
+			texts:   []string{"snake_case & more", " Usage ", "Usage"},
+			want:    []string{"snake_case-more", "usage", "usage-1"},
+		},
+		{
+			name:    "hugo",
+			slugger: NewHugoSlugger,
+			texts:   []string{"API (v2)", "Überblick", "Usage", "Usage"},
+			want:    []string{"api-v2", "überblick", "usage", "usage-1"},
+		},
+		{
+			name:    "mkdocs",
+			slugger: NewMkDocsSlugger,
+			texts:   []string{"Überblick", "API  (v2)", "Usage", "Usage", "Usage", "日本語"},
+			want:    []string{"uberblick", "api-v2", "usage", "usage_1", "usage_2", "_1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := tt.slugger()
+			for i, text := range tt.texts {
+				if got := s.Slug(text); got != tt.want[i] {
+					t.Fatalf("unexpected slug for %q\nwant: %q\ngot:  %q", text, tt.want[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestTableOfContentsDuplicateHeadings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default slugger", func(t *testing.T) {
+		t.Parallel()
+
+		md := NewMarkdown(io.Discard).
+			H2("Usage").
+			H3("Overview").
+			H2("Usage").
+			H3("Overview").
+			TableOfContents(TableOfContentsDepthH2)
+
+		lf := lineFeed()
+		want := strings.Join([]string{
+			"## Usage", "", "### Overview", "", "## Usage", "", "### Overview", "",
+			"  - [Usage](#usage)",
+			"  - [Usage](#usage-1)",
+		}, lf) + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+
+		html, err := md.HTML()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, id := range []string{`id="usage"`, `id="overview"`, `id="usage-1"`, `id="overview-1"`} {
+			if !strings.Contains(html, id) {
+				t.Fatalf("expected %s in HTML output:\n%s", id, html)
+			}
+		}
+	})
+
+	t.Run("custom slugger", func(t *testing.T) {
+		t.Parallel()
+
+		md := NewMarkdown(io.Discard, WithSlugger(NewMkDocsSlugger)).
+			H1("Usage").
+			H1("Usage").
+			TableOfContents(TableOfContentsDepthH1)
+
+		lf := lineFeed()
+		want := strings.Join([]string{
+			"# Usage", "", "# Usage", "",
+			"- [Usage](#usage)",
+			"- [Usage](#usage_1)",
+		}, lf) + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+}
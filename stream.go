@@ -0,0 +1,142 @@
+package markdown
+
+import (
+	"fmt"
+	"io"
+
+	tableast "github.com/yuin/goldmark/extension/ast"
+)
+
+// StreamOptions configures a TableWriter.
+type StreamOptions struct {
+	Alignment []TableAlignment
+	// Widths fixes the width cells are padded to. Wider cells are written
+	// whole and push the rest of their row to the right.
+	Widths []int
+	// SampleRows buffers that many rows before writing the header, and
+	// pads cells to the widest cell of the sample. Zero pads to the header.
+	// Ignored when Widths is set.
+	SampleRows int
+	// RawCells writes cell values as trusted Markdown without escaping.
+	RawCells bool
+}
+
+// TableWriter writes a table to an io.Writer one row at a time, without
+// holding the rows in memory, for tables too large to build with Table.
+type TableWriter struct {
+	dest       io.Writer
+	options    options
+	stream     StreamOptions
+	header     []string
+	alignments []tableast.Alignment
+	widths     []int
+	sample     [][]string
+	rows       int
+	started    bool
+	closed     bool
+	err        error
+}
+
+// NewTableWriter returns a TableWriter writing a table with header to w.
+// Options such as WithLineEnding, WithTablePadding and WithoutEscaping apply
+// as they do to Markdown. Call Close after the last row.
+func NewTableWriter(w io.Writer, header []string, stream StreamOptions, opts ...Option) *TableWriter {
+	t := &TableWriter{
+		dest:       w,
+		options:    newOptions(opts),
+		stream:     stream,
+		alignments: convertAlignments(TableSet{Header: header, Alignment: stream.Alignment}),
+	}
+	t.header = t.cells(header)
+	if len(stream.Widths) > 0 {
+		t.widths = make([]int, len(header))
+		copy(t.widths, stream.Widths)
+		t.widths = t.options.tablePadding(t.widths)
+	}
+	return t
+}
+
+// WriteRow writes a row of cells. A row whose number of cells doesn't match
+// the header is reported as a *ColumnMismatchError, whose Row counts every
+// row passed to WriteRow, and isn't written. Write errors are returned by
+// every later call, and rows written after Close by ErrTableWriterClosed.
+func (t *TableWriter) WriteRow(cells ...string) error {
+	if t.err != nil {
+		return t.err
+	}
+	if t.closed {
+		return ErrTableWriterClosed
+	}
+	index := t.rows
+	t.rows++
+	if len(cells) != len(t.header) {
+		return &ColumnMismatchError{Row: index, Columns: len(cells), Want: len(t.header)}
+	}
+	row := t.cells(cells)
+	if !t.started && t.widths == nil && len(t.sample) < t.stream.SampleRows {
+		t.sample = append(t.sample, row)
+		if len(t.sample) < t.stream.SampleRows {
+			return nil
+		}
+		row = nil
+	}
+	if err := t.start(); err != nil {
+		return err
+	}
+	if row == nil {
+		return nil
+	}
+	return t.writeLine(formatTableRow(row, t.widths, t.alignments))
+}
+
+// Close writes the header and sampled rows if they haven't been written,
+// which happens for tables with fewer rows than SampleRows. It doesn't close
+// the underlying writer.
+func (t *TableWriter) Close() error {
+	if t.err != nil {
+		return t.err
+	}
+	t.closed = true
+	return t.start()
+}
+
+// start writes the header, the delimiter row and the sampled rows once.
+func (t *TableWriter) start() error {
+	if t.started {
+		return nil
+	}
+	t.started = true
+	if t.widths == nil {
+		t.widths = t.options.tablePadding(computeColumnWidths(t.header, t.sample))
+	}
+	lines := []string{formatTableRow(t.header, t.widths, t.alignments), formatDelimiterRow(t.widths, t.alignments)}
+	for _, row := range t.sample {
+		lines = append(lines, formatTableRow(row, t.widths, t.alignments))
+	}
+	t.sample = nil
+	for _, line := range lines {
+		if err := t.writeLine(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TableWriter) writeLine(line string) error {
+	if _, err := io.WriteString(t.dest, line+t.options.lineFeed()); err != nil {
+		t.err = fmt.Errorf("failed to write table: %w", err)
+	}
+	return t.err
+}
+
+func (t *TableWriter) cells(values []string) []string {
+	cells := make([]string, len(values))
+	for i, value := range values {
+		if t.stream.RawCells || t.options.rawText {
+			cells[i] = value
+		} else {
+			cells[i] = escapeText(value, escapeTableCell, false)
+		}
+	}
+	return cells
+}
@@ -0,0 +1,124 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTableWriter(t *testing.T) {
+	t.Parallel()
+
+	rows := [][]string{{"1", "login", "alice"}, {"2", "delete|purge", "bob"}, {"3", "logout", "alice"}}
+	tests := []struct {
+		name   string
+		stream StreamOptions
+		want   []string
+	}{
+		{
+			name:   "header widths",
+			stream: StreamOptions{Alignment: []TableAlignment{AlignRight}},
+			want: []string{
+				"|  ID | Action | User |",
+				"| --: | ------ | ---- |",
+				"|   1 | login  | alice |",
+				"|   2 | delete\\|purge | bob  |",
+				"|   3 | logout | alice |",
+			},
+		},
+		{
+			name:   "sampled widths",
+			stream: StreamOptions{SampleRows: 2},
+			want: []string{
+				"| ID  | Action        | User  |",
+				"| --- | ------------- | ----- |",
+				"| 1   | login         | alice |",
+				"| 2   | delete\\|purge | bob   |",
+				"| 3   | logout        | alice |",
+			},
+		},
+		{
+			name:   "fixed widths",
+			stream: StreamOptions{Widths: []int{2, 8, 5}},
+			want: []string{
+				"| ID  | Action   | User  |",
+				"| --- | -------- | ----- |",
+				"| 1   | login    | alice |",
+				"| 2   | delete\\|purge | bob   |",
+				"| 3   | logout   | alice |",
+			},
+		},
+		{
+			name:   "sample larger than table",
+			stream: StreamOptions{SampleRows: 10},
+			want: []string{
+				"| ID  | Action        | User  |",
+				"| --- | ------------- | ----- |",
+				"| 1   | login         | alice |",
+				"| 2   | delete\\|purge | bob   |",
+				"| 3   | logout        | alice |",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			w := NewTableWriter(&buf, []string{"ID", "Action", "User"}, tt.stream, WithLineEnding("\n"))
+			for _, row := range rows {
+				if err := w.WriteRow(row...); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := strings.Join(tt.want, "\n") + "\n"
+			if got := buf.String(); got != want {
+				t.Fatalf("unexpected table\nwant: %q\ngot:  %q", want, got)
+			}
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestTableWriterErrors(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewTableWriter(&buf, []string{"A", "B"}, StreamOptions{}, WithLineEnding("\n"))
+	err := w.WriteRow("1")
+	var mismatch *ColumnMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Row != 0 {
+		t.Fatalf("expected a mismatch in row 1, got %v", err)
+	}
+	if err := w.WriteRow("1", "2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.WriteRow("1", "2", "3"); !errors.As(err, &mismatch) || mismatch.Row != 2 {
+		t.Fatalf("expected a mismatch in row 3, got %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Count(buf.String(), "|\n"); got != 3 {
+		t.Fatalf("expected the header and one row, got %q", buf.String())
+	}
+	if err := w.WriteRow("3", "4"); !errors.Is(err, ErrTableWriterClosed) {
+		t.Fatalf("expected ErrTableWriterClosed, got %v", err)
+	}
+
+	w = NewTableWriter(failingWriter{}, []string{"A"}, StreamOptions{})
+	if err := w.WriteRow("1"); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("expected the write error, got %v", err)
+	}
+	if err := w.Close(); err == nil {
+		t.Fatal("expected the write error from Close")
+	}
+}
@@ -0,0 +1,179 @@
+package markdown
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Aggregate is a summary computed from the values of a table column.
+type Aggregate int
+
+const (
+	// AggregateNone leaves the footer cell empty.
+	AggregateNone Aggregate = iota
+	// AggregateSum adds the numbers of the column.
+	AggregateSum
+	// AggregateAvg averages the numbers of the column.
+	AggregateAvg
+	// AggregateMin is the smallest number of the column.
+	AggregateMin
+	// AggregateMax is the largest number of the column.
+	AggregateMax
+	// AggregateCount counts the non-empty cells of the column.
+	AggregateCount
+)
+
+// Summarize sets Footer to a row of aggregates, the i-th of which summarizes
+// the i-th column. Values that aren't numbers are ignored by every aggregate
+// but AggregateCount. Numbers may use thousands separators and a percent
+// sign, and results are written in the same style with the largest number of
+// decimals of the inputs. label is written to the first column when it has
+// no aggregate.
+func (t *TableSet) Summarize(label string, aggregates ...Aggregate) error {
+	if len(aggregates) > len(t.Header) {
+		return &ColumnMismatchError{Row: len(t.Rows), Footer: true, Columns: len(aggregates), Want: len(t.Header)}
+	}
+	footer := make([]string, len(t.Header))
+	for column, aggregate := range aggregates {
+		footer[column] = t.aggregate(column, aggregate)
+	}
+	if len(footer) > 0 && (len(aggregates) == 0 || aggregates[0] == AggregateNone) {
+		footer[0] = label
+	}
+	t.Footer = footer
+	return nil
+}
+
+func (t *TableSet) aggregate(column int, aggregate Aggregate) string {
+	if aggregate == AggregateNone {
+		return ""
+	}
+	var values []float64
+	var style numberStyle
+	count := 0
+	for _, row := range t.Rows {
+		if column >= len(row) || strings.TrimSpace(row[column]) == "" {
+			continue
+		}
+		count++
+		value, s, ok := parseNumber(row[column])
+		if !ok {
+			continue
+		}
+		if len(values) == 0 {
+			style = s
+		} else {
+			style = style.merge(s)
+		}
+		values = append(values, value)
+	}
+
+	if aggregate == AggregateCount {
+		return strconv.Itoa(count)
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	var result float64
+	switch aggregate {
+	case AggregateSum, AggregateAvg:
+		for _, v := range values {
+			result += v
+		}
+		if aggregate == AggregateAvg {
+			result /= float64(len(values))
+			if style.decimals == 0 && result != math.Trunc(result) {
+				style.decimals = 2
+			}
+		}
+	case AggregateMin:
+		result = values[0]
+		for _, v := range values[1:] {
+			result = math.Min(result, v)
+		}
+	case AggregateMax:
+		result = values[0]
+		for _, v := range values[1:] {
+			result = math.Max(result, v)
+		}
+	default:
+		return ""
+	}
+	return style.format(result)
+}
+
+// numberStyle is how a number is written in a cell.
+type numberStyle struct {
+	decimals int
+	grouped  bool
+	percent  bool
+}
+
+func (s numberStyle) merge(other numberStyle) numberStyle {
+	if other.decimals > s.decimals {
+		s.decimals = other.decimals
+	}
+	s.grouped = s.grouped || other.grouped
+	s.percent = s.percent && other.percent
+	return s
+}
+
+func (s numberStyle) format(value float64) string {
+	text := strconv.FormatFloat(value, 'f', s.decimals, 64)
+	if s.grouped {
+		text = groupThousands(text, ",")
+	}
+	if s.percent {
+		text += "%"
+	}
+	return text
+}
+
+// parseNumber reads a number written as text, such as "1,204", "-3.5" or
+// "12%".
+func parseNumber(text string) (float64, numberStyle, bool) {
+	text = strings.TrimSpace(text)
+	if textKind(text) != cellNumber {
+		return 0, numberStyle{}, false
+	}
+	var style numberStyle
+	if strings.HasSuffix(text, "%") {
+		style.percent = true
+		text = strings.TrimSuffix(text, "%")
+	}
+	if strings.Contains(text, ",") {
+		style.grouped = true
+		text = strings.ReplaceAll(text, ",", "")
+	}
+	if dot := strings.IndexByte(text, '.'); dot >= 0 && !strings.ContainsAny(text, "eE") {
+		style.decimals = len(text) - dot - 1
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, numberStyle{}, false
+	}
+	return value, style, true
+}
+
+// groupThousands inserts sep between groups of three digits of the integer
+// part of a formatted number.
+func groupThousands(number, sep string) string {
+	sign := ""
+	if strings.HasPrefix(number, "-") || strings.HasPrefix(number, "+") {
+		sign, number = number[:1], number[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(number, ".")
+	var buf strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			buf.WriteString(sep)
+		}
+		buf.WriteRune(digit)
+	}
+	if hasFraction {
+		return fmt.Sprintf("%s%s.%s", sign, buf.String(), fraction)
+	}
+	return sign + buf.String()
+}
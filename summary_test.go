@@ -0,0 +1,77 @@
+package markdown
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestTableSetSummarize(t *testing.T) {
+	t.Parallel()
+
+	set := TableSet{
+		Header: []string{"Service", "Cost", "Latency", "Share", "Owner"},
+		Rows: [][]string{
+			{"api", "1,200.50", "12", "40%", "ops"},
+			{"db", "800", "30", "35%", ""},
+			{"cache", "n/a", "9", "25%", "web"},
+		},
+	}
+	if err := set.Summarize("Total", AggregateNone, AggregateSum, AggregateAvg, AggregateMax, AggregateCount); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"Total", "2,000.50", "17", "40%", "2"}
+	if !reflect.DeepEqual(set.Footer, want) {
+		t.Fatalf("unexpected footer\nwant: %q\ngot:  %q", want, set.Footer)
+	}
+
+	if err := set.Summarize("Min", AggregateNone, AggregateMin, AggregateAvg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = []string{"Min", "800.00", "17", "", ""}
+	if !reflect.DeepEqual(set.Footer, want) {
+		t.Fatalf("unexpected footer\nwant: %q\ngot:  %q", want, set.Footer)
+	}
+
+	set.Rows = append(set.Rows, []string{"queue", "1", "2", "0%", ""})
+	if err := set.Summarize("", AggregateNone, AggregateNone, AggregateAvg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := set.Footer[2]; got != "13.25" {
+		t.Fatalf("unexpected average: %q", got)
+	}
+
+	if err := set.Summarize("", make([]Aggregate, 6)...); !errors.Is(err, ErrMismatchColumn) {
+		t.Fatalf("expected ErrMismatchColumn, got %v", err)
+	}
+}
+
+func TestMarkdownTableFooter(t *testing.T) {
+	t.Parallel()
+
+	lf := lineFeed()
+	set := TableSet{
+		Header:    []string{"Item", "Cost"},
+		Rows:      [][]string{{"a", "2"}, {"b", "3"}},
+		Alignment: []TableAlignment{AlignLeft, AlignRight},
+	}
+	if err := set.Summarize("Total", AggregateNone, AggregateSum); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := NewMarkdown(io.Discard).Table(set)
+
+	want := "| Item      |  Cost |" + lf +
+		"| :-------- | ----: |" + lf +
+		"| a         |     2 |" + lf +
+		"| b         |     3 |" + lf +
+		"| **Total** | **5** |" + lf
+	if got := md.String(); got != want {
+		t.Fatalf("unexpected table output\nwant: %q\ngot:  %q", want, got)
+	}
+
+	set.Footer = []string{"only one"}
+	if err := NewMarkdown(io.Discard).Table(set).Error(); !errors.Is(err, ErrMismatchColumn) {
+		t.Fatalf("expected ErrMismatchColumn, got %v", err)
+	}
+}
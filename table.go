@@ -0,0 +1,86 @@
+package markdown
+
+import (
+	"github.com/yuin/goldmark/ast"
+	tableast "github.com/yuin/goldmark/extension/ast"
+)
+
+// TableBuilder builds a table whose cells hold inline content such as links,
+// code spans, badges and line breaks.
+type TableBuilder struct {
+	header    []string
+	alignment []TableAlignment
+	rows      [][]*tableast.TableCell
+}
+
+// RowBuilder builds the cells of a table row.
+type RowBuilder struct {
+	cells []*tableast.TableCell
+}
+
+// TableFunc appends a table built by fn. Rows are validated against the
+// header as they are for Table.
+func (m *Markdown) TableFunc(fn func(t *TableBuilder)) *Markdown {
+	builder := &TableBuilder{}
+	fn(builder)
+
+	set := TableSet{
+		Header:    builder.header,
+		Alignment: builder.alignment,
+		Rows:      make([][]string, len(builder.rows)),
+	}
+	for i, row := range builder.rows {
+		set.Rows[i] = make([]string, len(row))
+	}
+	return m.table(set, false, func(row, column int, _ string) []ast.Node {
+		var nodes []ast.Node
+		cell := builder.rows[row][column]
+		for child := cell.FirstChild(); child != nil; child = child.NextSibling() {
+			nodes = append(nodes, child)
+		}
+		return nodes
+	})
+}
+
+// Header sets the header cells.
+func (t *TableBuilder) Header(cells ...string) *TableBuilder {
+	t.header = cells
+	return t
+}
+
+// Align sets the alignment of the columns.
+func (t *TableBuilder) Align(alignment ...TableAlignment) *TableBuilder {
+	t.alignment = alignment
+	return t
+}
+
+// Row appends a row of plain text cells.
+func (t *TableBuilder) Row(cells ...string) *TableBuilder {
+	return t.RowFunc(func(r *RowBuilder) {
+		for _, cell := range cells {
+			r.Cell(cell)
+		}
+	})
+}
+
+// RowFunc appends a row whose cells are built by fn.
+func (t *TableBuilder) RowFunc(fn func(r *RowBuilder)) *TableBuilder {
+	row := &RowBuilder{}
+	fn(row)
+	t.rows = append(t.rows, row.cells)
+	return t
+}
+
+// Cell appends a plain text cell.
+func (r *RowBuilder) Cell(text string) *RowBuilder {
+	return r.CellFunc(func(c *Inline) { c.Text(text) })
+}
+
+// CellFunc appends a cell built from inline content. Line breaks are
+// written as <br>.
+func (r *RowBuilder) CellFunc(fn func(c *Inline)) *RowBuilder {
+	cell := tableast.NewTableCell()
+	fn(newInline(cell))
+	r.cells = append(r.cells, cell)
+	return r
+}
@@ -0,0 +1,91 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownTableFunc(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	md := NewMarkdown(&buf, WithLineEnding("\n")).
+		TableFunc(func(t *TableBuilder) {
+			t.Header("Service", "Status", "Checks").
+				Align(AlignLeft, AlignCenter, AlignLeft).
+				RowFunc(func(r *RowBuilder) {
+					r.CellFunc(func(c *Inline) { c.Link("api", "https://example.com/api") })
+					r.CellFunc(func(c *Inline) { c.Badge("up", "green") })
+					r.CellFunc(func(c *Inline) {
+						c.CheckBox(true).Text("lint").LineBreak().CheckBox(false).Code("go test")
+					})
+				}).
+				Row("db|primary", "down", "")
+		})
+	if err := md.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"| Service                        |                    Status                    | Checks      |",
+		"| :----------------------------- | :------------------------------------------: | :---------- |",
+		"| [api](https://example.com/api) | ![up](https://img.shields.io/badge/up-green) | ☑ lint<br>☐ `go test` |",
+		"| db\\|primary                    |                     down                     |             |",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected table\nwant: %q\ngot:  %q", want, got)
+	}
+
+	sets := md.Tables()
+	if len(sets) != 1 {
+		t.Fatalf("expected one table, got %d", len(sets))
+	}
+	if got := sets[0].Rows[0][2]; got != "lint\ngo test" {
+		t.Fatalf("unexpected cell text: %q", got)
+	}
+	if got := sets[0].Rows[1][0]; got != "db|primary" {
+		t.Fatalf("unexpected cell text: %q", got)
+	}
+
+	html, err := md.HTML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, fragment := range []string{
+		`<a href="https://example.com/api">api</a>`,
+		`<img src="https://img.shields.io/badge/up-green" alt="up">`,
+		`lint<br>`,
+		`<code>go test</code>`,
+	} {
+		if !strings.Contains(html, fragment) {
+			t.Fatalf("expected %q in html:\n%s", fragment, html)
+		}
+	}
+}
+
+func TestMarkdownTableFuncMismatch(t *testing.T) {
+	t.Parallel()
+
+	err := NewMarkdown(&bytes.Buffer{}).
+		TableFunc(func(t *TableBuilder) {
+			t.Header("A", "B").Row("1")
+		}).
+		Build()
+	if !errors.Is(err, ErrMismatchColumn) {
+		t.Fatalf("expected ErrMismatchColumn, got %v", err)
+	}
+}
+
+func TestInlineLineBreak(t *testing.T) {
+	t.Parallel()
+
+	got := NewMarkdown(&bytes.Buffer{}, WithLineEnding("\n")).
+		Paragraph(func(p *Inline) { p.Text("first").LineBreak().Text("second") }).
+		String()
+	if want := "first  \nsecond\n"; got != want {
+		t.Fatalf("unexpected paragraph\nwant: %q\ngot:  %q", want, got)
+	}
+}
@@ -0,0 +1,235 @@
+package markdown
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TableOfOption configures how TableOf turns struct fields into cells.
+type TableOfOption func(*tableOfConfig)
+
+type tableOfConfig struct {
+	timeLayout string
+	nilText    string
+}
+
+// WithTimeLayout sets the layout of time.Time fields without a format tag.
+// The default is time.RFC3339.
+func WithTimeLayout(layout string) TableOfOption {
+	return func(c *tableOfConfig) {
+		c.timeLayout = layout
+	}
+}
+
+// WithNilText sets the text written for nil pointer and interface fields.
+// The default is an empty cell.
+func WithNilText(text string) TableOfOption {
+	return func(c *tableOfConfig) {
+		c.nilText = text
+	}
+}
+
+// tableColumn is a struct field rendered as a table column.
+type tableColumn struct {
+	index     []int
+	header    string
+	align     TableAlignment
+	format    string
+	omitEmpty bool
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// TableOf builds a TableSet from a slice of structs or struct pointers, with
+// one column per exported field. Fields of embedded structs are promoted.
+// The md struct tag configures a column:
+//
+//	Price float64 `md:"Unit Price,align=right,format=%.2f"`
+//	Day   time.Time `md:"Date,format=2006-01-02"`
+//	Notes string  `md:",omitempty"`
+//	ID    int     `md:"-"`
+//
+// The first tag value is the header, which defaults to the field name. align
+// is left, center or right. format is a fmt verb, or a time layout for
+// time.Time fields. omitempty leaves cells of zero values empty, and "-" or
+// omit leaves the field out. Values implementing fmt.Stringer are written
+// with String, pointers are dereferenced, and nil elements of rows are
+// skipped.
+func TableOf[T any](rows []T, opts ...TableOfOption) (TableSet, error) {
+	config := tableOfConfig{timeLayout: time.RFC3339}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	elemType := reflect.TypeOf((*T)(nil)).Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return TableSet{}, fmt.Errorf("%w: %s", ErrNotStruct, elemType)
+	}
+
+	columns, err := structColumns(structType, nil)
+	if err != nil {
+		return TableSet{}, err
+	}
+	set := TableSet{
+		Header:    make([]string, len(columns)),
+		Alignment: make([]TableAlignment, len(columns)),
+		Rows:      make([][]string, 0, len(rows)),
+	}
+	for i, column := range columns {
+		set.Header[i] = column.header
+		set.Alignment[i] = column.align
+	}
+
+	values := reflect.ValueOf(rows)
+	for i := 0; i < values.Len(); i++ {
+		value := values.Index(i)
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			field, ok := fieldByIndex(value, column.index)
+			if !ok {
+				row[j] = config.nilText
+				continue
+			}
+			row[j] = config.formatCell(field, column)
+		}
+		set.Rows = append(set.Rows, row)
+	}
+	return set, nil
+}
+
+func structColumns(structType reflect.Type, parent []int) ([]tableColumn, error) {
+	var columns []tableColumn
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		index := append(append([]int{}, parent...), i)
+		tag, tagged := field.Tag.Lookup("md")
+		if tag == "-" {
+			continue
+		}
+
+		embedded := field.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if field.Anonymous && !tagged && embedded.Kind() == reflect.Struct && embedded != timeType {
+			nested, err := structColumns(embedded, index)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, nested...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		column, omit, err := parseColumnTag(field.Name, tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if omit {
+			continue
+		}
+		column.index = index
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func parseColumnTag(name, tag string) (tableColumn, bool, error) {
+	column := tableColumn{header: name}
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		column.header = parts[0]
+	}
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "align":
+			switch value {
+			case "left":
+				column.align = AlignLeft
+			case "center":
+				column.align = AlignCenter
+			case "right":
+				column.align = AlignRight
+			case "", "default":
+				column.align = AlignDefault
+			default:
+				return column, false, fmt.Errorf("%w: unknown alignment %q", ErrInvalidTag, value)
+			}
+		case "format":
+			column.format = value
+		case "omitempty":
+			column.omitEmpty = true
+		case "omit":
+			return column, true, nil
+		case "":
+		default:
+			return column, false, fmt.Errorf("%w: unknown option %q", ErrInvalidTag, key)
+		}
+	}
+	return column, false, nil
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex that reports nil embedded
+// pointers instead of panicking.
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+			value = value.Elem()
+		}
+		value = value.Field(x)
+	}
+	return value, true
+}
+
+func (c tableOfConfig) formatCell(value reflect.Value, column tableColumn) string {
+	if column.omitEmpty && value.IsZero() {
+		return ""
+	}
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return c.nilText
+		}
+		value = value.Elem()
+	}
+
+	if value.Type() == timeType {
+		layout := c.timeLayout
+		if column.format != "" {
+			layout = column.format
+		}
+		return value.Interface().(time.Time).Format(layout)
+	}
+	if !value.CanInterface() {
+		return fmt.Sprint(value)
+	}
+	v := value.Interface()
+	// Prefer a String method declared on the pointer receiver.
+	if value.CanAddr() && !value.Type().Implements(stringerType) && reflect.PointerTo(value.Type()).Implements(stringerType) {
+		v = value.Addr().Interface()
+	}
+	if column.format != "" {
+		return fmt.Sprintf(column.format, v)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,95 @@
+package markdown
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type tableOfStatus int
+
+func (s tableOfStatus) String() string {
+	if s == 1 {
+		return "active"
+	}
+	return "inactive"
+}
+
+type tableOfVersion struct {
+	major, minor int
+}
+
+func (v *tableOfVersion) String() string {
+	return "v" + string(rune('0'+v.major)) + "." + string(rune('0'+v.minor))
+}
+
+type tableOfAudit struct {
+	Owner string `md:"Owner"`
+}
+
+type tableOfService struct {
+	Name    string
+	Price   float64        `md:"Unit Price,align=right,format=%.2f"`
+	Since   time.Time      `md:"Since,format=2006-01-02"`
+	Updated *time.Time     `md:",omitempty"`
+	Status  tableOfStatus  `md:",align=center"`
+	Version tableOfVersion `md:"Version"`
+	Replica *int
+	Secret  string `md:"-"`
+	Debug   bool   `md:"Debug,omit"`
+	hidden  string
+	*tableOfAudit
+}
+
+func TestTableOf(t *testing.T) {
+	t.Parallel()
+
+	replicas := 3
+	updated := time.Date(2024, 10, 2, 12, 0, 0, 0, time.UTC)
+	services := []*tableOfService{
+		{
+			Name:         "api",
+			Price:        12.5,
+			Since:        time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+			Updated:      &updated,
+			Status:       1,
+			Version:      tableOfVersion{1, 2},
+			Replica:      &replicas,
+			tableOfAudit: &tableOfAudit{Owner: "ops"},
+		},
+		nil,
+		{Name: "db", Since: time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC)},
+	}
+
+	set, err := TableOf(services, WithNilText("n/a"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := TableSet{
+		Header: []string{"Name", "Unit Price", "Since", "Updated", "Status", "Version", "Replica", "Owner"},
+		Rows: [][]string{
+			{"api", "12.50", "2024-10-01", "2024-10-02T12:00:00Z", "active", "v1.2", "3", "ops"},
+			{"db", "0.00", "2023-01-05", "", "inactive", "v0.0", "n/a", "n/a"},
+		},
+		Alignment: []TableAlignment{AlignDefault, AlignRight, AlignDefault, AlignDefault, AlignCenter, AlignDefault, AlignDefault, AlignDefault},
+	}
+	if !reflect.DeepEqual(set, want) {
+		t.Fatalf("unexpected table set\nwant: %#v\ngot:  %#v", want, set)
+	}
+}
+
+func TestTableOfErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := TableOf([]int{1}); !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("expected ErrNotStruct, got %v", err)
+	}
+
+	type badAlign struct {
+		A int `md:",align=middle"`
+	}
+	if _, err := TableOf([]badAlign{{}}); !errors.Is(err, ErrInvalidTag) {
+		t.Fatalf("expected ErrInvalidTag, got %v", err)
+	}
+}
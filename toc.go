@@ -0,0 +1,169 @@
+package markdown
+
+import (
+	"github.com/yuin/goldmark/ast"
+)
+
+// TableOfContentsOptions configures a table of contents added with
+// CustomTableOfContents.
+type TableOfContentsOptions struct {
+	// MinDepth is the shallowest heading level listed. Zero means H1.
+	MinDepth TableOfContentsDepth
+	// MaxDepth is the deepest heading level listed. Zero means H6.
+	MaxDepth TableOfContentsDepth
+	// Numbered lists entries in ordered lists instead of bullet lists.
+	Numbered bool
+	// Title is written as a heading above the entries when not empty.
+	Title string
+	// TitleLevel is the level of the title heading. Zero means H2.
+	TitleLevel int
+	// Exclude lists the text of headings left out of the contents.
+	Exclude []string
+}
+
+// CustomTableOfContents adds a table of contents that is resolved when the
+// document is rendered, so it lists every heading of the document, including
+// the ones added after it and the ones in parsed source.
+func (m *Markdown) CustomTableOfContents(options TableOfContentsOptions) *Markdown {
+	m.appendBlock(newTOCBlock(options))
+	return m
+}
+
+// tocEntry is a heading listed in a table of contents.
+type tocEntry struct {
+	heading *ast.Heading
+	level   int
+	text    string
+	anchor  string
+}
+
+// resolveTableOfContents rebuilds the content of every table of contents
+// placeholder from the headings currently in the document.
+func (m *Markdown) resolveTableOfContents() {
+	var placeholders []*tocBlock
+	_ = ast.Walk(m.doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if toc, ok := node.(*tocBlock); ok && entering {
+			placeholders = append(placeholders, toc)
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	if len(placeholders) == 0 {
+		return
+	}
+
+	// Titles are headings of the document too and take part in slugging.
+	for _, toc := range placeholders {
+		toc.RemoveChildren(toc)
+		if toc.options.Title != "" {
+			level := toc.options.TitleLevel
+			if level < 1 || level > 6 {
+				level = 2
+			}
+			title := ast.NewHeading(min(level+toc.shift, 6))
+			title.AppendChild(title, textNode(toc.options.Title))
+			toc.AppendChild(toc, title)
+		}
+	}
+
+	r := m.newRenderer()
+	slugger := m.options.slugger()
+	var entries []tocEntry
+	_ = ast.Walk(m.doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		h, ok := node.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		text := r.collectPlainText(h)
+		anchor := slugger.Slug(text)
+		if _, title := h.Parent().(*tocBlock); !title {
+			entries = append(entries, tocEntry{heading: h, level: h.Level, text: text, anchor: anchor})
+		}
+		return ast.WalkSkipChildren, nil
+	})
+
+	for _, toc := range placeholders {
+		if list := toc.buildList(entries); list != nil {
+			toc.AppendChild(toc, list)
+		}
+	}
+}
+
+// buildList nests each entry under the closest preceding entry of a lower
+// level, so skipped heading levels don't produce empty items.
+func (n *tocBlock) buildList(entries []tocEntry) *ast.List {
+	minDepth, maxDepth := int(n.options.MinDepth), int(n.options.MaxDepth)
+	if minDepth == 0 {
+		minDepth = 1
+	}
+	if maxDepth == 0 {
+		maxDepth = 6
+	}
+	excluded := make(map[string]bool, len(n.options.Exclude))
+	for _, text := range n.options.Exclude {
+		excluded[text] = true
+	}
+
+	type frame struct {
+		level int
+		list  *ast.List
+	}
+	var root *ast.List
+	var stack []frame
+	for _, entry := range entries {
+		if n.headings != nil && !n.headings[entry.heading] {
+			continue
+		}
+		// Depths refer to the levels the headings had before inclusion.
+		if level := entry.level - n.shift; level < minDepth || level > maxDepth || excluded[entry.text] {
+			continue
+		}
+		for len(stack) > 0 && stack[len(stack)-1].level >= entry.level {
+			stack = stack[:len(stack)-1]
+		}
+		var list *ast.List
+		switch {
+		case root == nil:
+			root = n.newList()
+			list = root
+		case len(stack) == 0:
+			list = root
+		default:
+			parent := stack[len(stack)-1]
+			item := parent.list.LastChild()
+			if nested, ok := item.LastChild().(*ast.List); ok {
+				list = nested
+			} else {
+				list = n.newList()
+				item.AppendChild(item, list)
+			}
+		}
+		list.AppendChild(list, newTOCItem(entry))
+		stack = append(stack, frame{level: entry.level, list: list})
+	}
+	return root
+}
+
+func (n *tocBlock) newList() *ast.List {
+	marker := byte('-')
+	if n.options.Numbered {
+		marker = '.'
+	}
+	list := ast.NewList(marker)
+	if list.IsOrdered() {
+		list.Start = 1
+	}
+	list.IsTight = true
+	return list
+}
+
+func newTOCItem(entry tocEntry) ast.Node {
+	link := ast.NewLink()
+	link.Destination = []byte(escapeLinkDestination("#" + entry.anchor))
+	link.AppendChild(link, textNode(entry.text))
+	textBlock := ast.NewTextBlock()
+	textBlock.AppendChild(textBlock, link)
+	item := ast.NewListItem(0)
+	item.AppendChild(item, textBlock)
+	return item
+}
@@ -0,0 +1,128 @@
+package markdown
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCustomTableOfContents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resolves headings added later", func(t *testing.T) {
+		t.Parallel()
+
+		md := NewMarkdown(io.Discard).
+			H1("Guide").
+			CustomTableOfContents(TableOfContentsOptions{
+				MinDepth: TableOfContentsDepthH2,
+				MaxDepth: TableOfContentsDepthH3,
+				Title:    "Contents",
+				Exclude:  []string{"License"},
+			}).
+			H2("Install").
+			H4("Too deep").
+			H3("From source").
+			H2("Usage").
+			H2("License")
+
+		lf := lineFeed()
+		want := strings.Join([]string{
+			"# Guide",
+			"",
+			"## Contents",
+			"",
+			"- [Install](#install)",
+			"  - [From source](#from-source)",
+			"- [Usage](#usage)",
+			"",
+			"## Install",
+			"",
+			"#### Too deep",
+			"",
+			"### From source",
+			"",
+			"## Usage",
+			"",
+			"## License",
+		}, lf) + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+		// Rendering again must not duplicate the entries.
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output on second render\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("numbered", func(t *testing.T) {
+		t.Parallel()
+
+		md := NewMarkdown(io.Discard).
+			CustomTableOfContents(TableOfContentsOptions{Numbered: true}).
+			H1("Intro").
+			H3("Details").
+			H1("Intro")
+
+		lf := lineFeed()
+		want := strings.Join([]string{
+			"1. [Intro](#intro)",
+			"   1. [Details](#details)",
+			"2. [Intro](#intro-1)",
+			"",
+			"# Intro",
+			"",
+			"### Details",
+			"",
+			"# Intro",
+		}, lf) + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("title takes part in slugging", func(t *testing.T) {
+		t.Parallel()
+
+		md := NewMarkdown(io.Discard).
+			CustomTableOfContents(TableOfContentsOptions{Title: "Contents"}).
+			H2("Contents")
+
+		got, err := md.HTML()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := `<h2 id="contents">Contents</h2>
+<ul>
+<li><a href="#contents-1">Contents</a></li>
+</ul>
+<h2 id="contents-1">Contents</h2>
+`
+		if got != want {
+			t.Fatalf("unexpected html output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+
+	t.Run("parsed headings", func(t *testing.T) {
+		t.Parallel()
+
+		md, err := Parse(io.Discard, []byte("> ## Quoted\n\n## Plain\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		md.CustomTableOfContents(TableOfContentsOptions{})
+
+		lf := lineFeed()
+		want := strings.Join([]string{
+			"> ## Quoted",
+			"",
+			"## Plain",
+			"",
+			"- [Quoted](#quoted)",
+			"- [Plain](#plain)",
+		}, lf) + lf
+		if got := md.String(); got != want {
+			t.Fatalf("unexpected markdown output\nwant: %q\ngot:  %q", want, got)
+		}
+	})
+}
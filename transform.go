@@ -0,0 +1,283 @@
+package markdown
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// columnIndex returns the index of the column with the given header.
+func (t TableSet) columnIndex(column string) (int, error) {
+	for i, header := range t.Header {
+		if header == column {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+}
+
+// copyRows returns a copy of rows that doesn't share their backing arrays.
+func copyRows(rows [][]string) [][]string {
+	copied := make([][]string, len(rows))
+	for i, row := range rows {
+		copied[i] = append([]string(nil), row...)
+	}
+	return copied
+}
+
+// SortBy returns a copy of the table with its rows sorted by the values of
+// column. Numbers, including values such as "1,204" or "12%", are compared
+// numerically and sort before text, which is compared case-insensitively;
+// desc reverses both orders but keeps text last. Rows with equal values keep
+// their order. The footer is kept and cell spans are dropped.
+func (t TableSet) SortBy(column string, desc bool) (TableSet, error) {
+	index, err := t.columnIndex(column)
+	if err != nil {
+		return TableSet{}, err
+	}
+	if err := t.ValidateColumns(); err != nil {
+		return TableSet{}, err
+	}
+	sorted := t
+	sorted.Rows = copyRows(t.Rows)
+	sorted.Spans = nil
+	sort.SliceStable(sorted.Rows, func(i, j int) bool {
+		return compareCells(sorted.Rows[i][index], sorted.Rows[j][index], desc) < 0
+	})
+	return sorted, nil
+}
+
+// compareCells orders numbers before text. Only the order among numbers and
+// among text is reversed by desc.
+func compareCells(a, b string, desc bool) int {
+	x, _, xNumber := parseNumber(a)
+	y, _, yNumber := parseNumber(b)
+	var c int
+	switch {
+	case xNumber && yNumber:
+		c = cmp.Compare(x, y)
+	case xNumber:
+		return -1
+	case yNumber:
+		return 1
+	default:
+		c = strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		if c == 0 {
+			c = strings.Compare(a, b)
+		}
+	}
+	if desc {
+		return -c
+	}
+	return c
+}
+
+// Filter returns a copy of the table holding the rows for which keep reports
+// true. The footer is dropped, as it may summarize the removed rows, and so
+// are cell spans.
+func (t TableSet) Filter(keep func(row []string) bool) TableSet {
+	filtered := t
+	filtered.Rows = [][]string{}
+	filtered.Footer = nil
+	filtered.Spans = nil
+	for _, row := range t.Rows {
+		if keep(row) {
+			filtered.Rows = append(filtered.Rows, append([]string(nil), row...))
+		}
+	}
+	return filtered
+}
+
+// TableGroup is the rows of a table sharing the value of a column.
+type TableGroup struct {
+	// Key is the value of the grouping column.
+	Key string
+	// Table holds the rows of the group without the grouping column.
+	Table TableSet
+}
+
+// GroupBy splits the rows of the table by the value of column, in order of
+// first appearance. The grouping column is removed from the group tables and
+// the footer is dropped. Render the groups with GroupedTable, or write a
+// heading and a Table for each of them.
+func (t TableSet) GroupBy(column string) ([]TableGroup, error) {
+	index, err := t.columnIndex(column)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.ValidateColumns(); err != nil {
+		return nil, err
+	}
+
+	template := TableSet{Header: removeColumn(t.Header, index), Rows: [][]string{}}
+	if index < len(t.Alignment) {
+		template.Alignment = removeColumn(t.Alignment, index)
+	} else {
+		template.Alignment = append([]TableAlignment(nil), t.Alignment...)
+	}
+
+	var groups []TableGroup
+	positions := map[string]int{}
+	for _, row := range t.Rows {
+		key := row[index]
+		position, ok := positions[key]
+		if !ok {
+			position = len(groups)
+			positions[key] = position
+			group := TableGroup{Key: key, Table: template}
+			group.Table.Header = slices.Clone(template.Header)
+			group.Table.Alignment = slices.Clone(template.Alignment)
+			groups = append(groups, group)
+		}
+		groups[position].Table.Rows = append(groups[position].Table.Rows, removeColumn(row, index))
+	}
+	return groups, nil
+}
+
+// removeColumn returns a copy of row without the value at index.
+func removeColumn[T any](row []T, index int) []T {
+	result := append([]T(nil), row[:index]...)
+	return append(result, row[index+1:]...)
+}
+
+// GroupedTable renders groups as one table, each group introduced by a row
+// holding its key in bold. The groups are expected to share a header, such
+// as the groups returned by GroupBy; the header and alignment of the first
+// group are used.
+func (m *Markdown) GroupedTable(groups []TableGroup) *Markdown {
+	if len(groups) == 0 {
+		return m
+	}
+	set := TableSet{
+		Header:    groups[0].Table.Header,
+		Alignment: groups[0].Table.Alignment,
+		Rows:      [][]string{},
+	}
+	keys := map[int]bool{}
+	for _, group := range groups {
+		keys[len(set.Rows)] = true
+		row := make([]string, len(set.Header))
+		if len(row) > 0 {
+			row[0] = group.Key
+		}
+		set.Rows = append(set.Rows, row)
+		set.Rows = append(set.Rows, group.Table.Rows...)
+	}
+	return m.table(set, false, func(row, _ int, text string) []ast.Node {
+		if !keys[row] || text == "" {
+			return []ast.Node{textNode(text)}
+		}
+		strong := ast.NewEmphasis(2)
+		strong.AppendChild(strong, textNode(text))
+		return []ast.Node{strong}
+	})
+}
+
+// Transpose returns the table with rows and columns swapped. The first
+// column becomes the header, so that a table listing one record per row
+// lists one record per column. A footer becomes the last column.
+func (t TableSet) Transpose() (TableSet, error) {
+	if err := t.ValidateColumns(); err != nil {
+		return TableSet{}, err
+	}
+	if len(t.Header) == 0 {
+		return TableSet{Rows: [][]string{}}, nil
+	}
+	records := append([][]string{t.Header}, t.Rows...)
+	if t.Footer != nil {
+		records = append(records, t.Footer)
+	}
+	transposed := TableSet{
+		Header: make([]string, len(records)),
+		Rows:   make([][]string, len(t.Header)-1),
+	}
+	for i, record := range records {
+		transposed.Header[i] = record[0]
+	}
+	for column := 1; column < len(t.Header); column++ {
+		row := make([]string, len(records))
+		for i, record := range records {
+			row[i] = record[column]
+		}
+		transposed.Rows[column-1] = row
+	}
+	return transposed, nil
+}
+
+// Pivot returns a table with a row for each distinct value of rowKey and a
+// column for each distinct value of colKey, both in order of first
+// appearance, holding the values of value. Several values for the same cell
+// are summed when they are all numbers; otherwise Pivot returns an
+// ErrPivotConflict error. Missing cells are empty, and the value columns
+// take the alignment of value.
+func (t TableSet) Pivot(rowKey, colKey, value string) (TableSet, error) {
+	var indexes [3]int
+	for i, column := range []string{rowKey, colKey, value} {
+		index, err := t.columnIndex(column)
+		if err != nil {
+			return TableSet{}, err
+		}
+		indexes[i] = index
+	}
+	if err := t.ValidateColumns(); err != nil {
+		return TableSet{}, err
+	}
+
+	var rowValues, colValues []string
+	rowPositions, colPositions := map[string]int{}, map[string]int{}
+	cells := map[[2]int][]string{}
+	for _, record := range t.Rows {
+		r, ok := rowPositions[record[indexes[0]]]
+		if !ok {
+			r = len(rowValues)
+			rowPositions[record[indexes[0]]] = r
+			rowValues = append(rowValues, record[indexes[0]])
+		}
+		c, ok := colPositions[record[indexes[1]]]
+		if !ok {
+			c = len(colValues)
+			colPositions[record[indexes[1]]] = c
+			colValues = append(colValues, record[indexes[1]])
+		}
+		cells[[2]int{r, c}] = append(cells[[2]int{r, c}], record[indexes[2]])
+	}
+
+	pivot := TableSet{
+		Header:    append([]string{rowKey}, colValues...),
+		Rows:      make([][]string, len(rowValues)),
+		Alignment: make([]TableAlignment, len(colValues)+1),
+	}
+	if indexes[0] < len(t.Alignment) {
+		pivot.Alignment[0] = t.Alignment[indexes[0]]
+	}
+	if indexes[2] < len(t.Alignment) {
+		for i := range colValues {
+			pivot.Alignment[i+1] = t.Alignment[indexes[2]]
+		}
+	}
+	for r, key := range rowValues {
+		row := make([]string, len(colValues)+1)
+		row[0] = key
+		for c := range colValues {
+			values := cells[[2]int{r, c}]
+			if len(values) <= 1 {
+				row[c+1] = strings.Join(values, "")
+				continue
+			}
+			sum := TableSet{Header: []string{value}}
+			for _, v := range values {
+				if _, _, ok := parseNumber(v); !ok {
+					return TableSet{}, fmt.Errorf("%w: %s %q, %s %q", ErrPivotConflict, rowKey, key, colKey, colValues[c])
+				}
+				sum.Rows = append(sum.Rows, []string{v})
+			}
+			row[c+1] = sum.aggregate(0, AggregateSum)
+		}
+		pivot.Rows[r] = row
+	}
+	return pivot, nil
+}
@@ -0,0 +1,178 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func scoreTable() TableSet {
+	return TableSet{
+		Header:    []string{"Player", "Team", "Quarter", "Score"},
+		Alignment: []TableAlignment{AlignLeft, AlignLeft, AlignLeft, AlignRight},
+		Rows: [][]string{
+			{"ana", "red", "Q1", "1,200"},
+			{"bob", "blue", "Q1", "950"},
+			{"cid", "red", "Q2", "n/a"},
+			{"dee", "blue", "Q2", "2,100"},
+			{"eve", "red", "Q1", "300"},
+		},
+	}
+}
+
+func TestTableSetSortBy(t *testing.T) {
+	t.Parallel()
+
+	set := scoreTable()
+	sorted, err := set.SortBy("Score", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, row := range sorted.Rows {
+		got = append(got, row[0])
+	}
+	want := []string{"dee", "ana", "bob", "eve", "cid"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected order\nwant: %q\ngot:  %q", want, got)
+	}
+	if set.Rows[0][0] != "ana" {
+		t.Fatalf("SortBy modified the original table: %q", set.Rows)
+	}
+
+	sorted, err = set.SortBy("Score", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got = got[:0]
+	for _, row := range sorted.Rows {
+		got = append(got, row[0])
+	}
+	want = []string{"eve", "bob", "ana", "dee", "cid"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected order\nwant: %q\ngot:  %q", want, got)
+	}
+
+	sorted, err = set.SortBy("Team", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got = got[:0]
+	for _, row := range sorted.Rows {
+		got = append(got, row[0])
+	}
+	want = []string{"bob", "dee", "ana", "cid", "eve"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected order\nwant: %q\ngot:  %q", want, got)
+	}
+
+	if _, err := set.SortBy("Rank", false); !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("expected ErrUnknownColumn, got %v", err)
+	}
+}
+
+func TestTableSetFilter(t *testing.T) {
+	t.Parallel()
+
+	set := scoreTable()
+	set.Footer = []string{"Total", "", "", "4,550"}
+	filtered := set.Filter(func(row []string) bool { return row[1] == "blue" })
+	want := [][]string{{"bob", "blue", "Q1", "950"}, {"dee", "blue", "Q2", "2,100"}}
+	if !reflect.DeepEqual(filtered.Rows, want) {
+		t.Fatalf("unexpected rows\nwant: %q\ngot:  %q", want, filtered.Rows)
+	}
+	if filtered.Footer != nil {
+		t.Fatalf("unexpected footer: %q", filtered.Footer)
+	}
+}
+
+func TestTableSetGroupBy(t *testing.T) {
+	t.Parallel()
+
+	groups, err := scoreTable().GroupBy("Team")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Key != "red" || groups[1].Key != "blue" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+	wantHeader := []string{"Player", "Quarter", "Score"}
+	if !reflect.DeepEqual(groups[1].Table.Header, wantHeader) {
+		t.Fatalf("unexpected header\nwant: %q\ngot:  %q", wantHeader, groups[1].Table.Header)
+	}
+	wantRows := [][]string{{"bob", "Q1", "950"}, {"dee", "Q2", "2,100"}}
+	if !reflect.DeepEqual(groups[1].Table.Rows, wantRows) {
+		t.Fatalf("unexpected rows\nwant: %q\ngot:  %q", wantRows, groups[1].Table.Rows)
+	}
+
+	var buf bytes.Buffer
+	if err := NewMarkdown(&buf, WithLineEnding("\n")).GroupedTable(groups).Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := strings.Join([]string{
+		"| Player   | Quarter | Score |",
+		"| :------- | :------ | ----: |",
+		"| **red**  |         |       |",
+		"| ana      | Q1      | 1,200 |",
+		"| cid      | Q2      |   n/a |",
+		"| eve      | Q1      |   300 |",
+		"| **blue** |         |       |",
+		"| bob      | Q1      |   950 |",
+		"| dee      | Q2      | 2,100 |",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected table\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+func TestTableSetTranspose(t *testing.T) {
+	t.Parallel()
+
+	set := TableSet{
+		Header: []string{"Metric", "Mon", "Tue"},
+		Rows:   [][]string{{"visits", "10", "12"}, {"signups", "1", "3"}},
+		Footer: []string{"Total", "11", "15"},
+	}
+	transposed, err := set.Transpose()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := TableSet{
+		Header: []string{"Metric", "visits", "signups", "Total"},
+		Rows:   [][]string{{"Mon", "10", "1", "11"}, {"Tue", "12", "3", "15"}},
+	}
+	if !reflect.DeepEqual(transposed, want) {
+		t.Fatalf("unexpected table\nwant: %+v\ngot:  %+v", want, transposed)
+	}
+
+	set.Rows[0] = set.Rows[0][:2]
+	if _, err := set.Transpose(); !errors.Is(err, ErrMismatchColumn) {
+		t.Fatalf("expected ErrMismatchColumn, got %v", err)
+	}
+}
+
+func TestTableSetPivot(t *testing.T) {
+	t.Parallel()
+
+	set := scoreTable()
+	set.Rows[2][3] = "400"
+	pivot, err := set.Pivot("Team", "Quarter", "Score")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := TableSet{
+		Header:    []string{"Team", "Q1", "Q2"},
+		Rows:      [][]string{{"red", "1,500", "400"}, {"blue", "950", "2,100"}},
+		Alignment: []TableAlignment{AlignLeft, AlignRight, AlignRight},
+	}
+	if !reflect.DeepEqual(pivot, want) {
+		t.Fatalf("unexpected table\nwant: %+v\ngot:  %+v", want, pivot)
+	}
+
+	if _, err := set.Pivot("Team", "Quarter", "Player"); !errors.Is(err, ErrPivotConflict) {
+		t.Fatalf("expected ErrPivotConflict, got %v", err)
+	}
+}
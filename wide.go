@@ -0,0 +1,150 @@
+package markdown
+
+import (
+	"slices"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// tableWidths returns the width of each column of set as Table writes it.
+func tableWidths(set TableSet, raw bool) []int {
+	cell := func(text string) string {
+		if raw {
+			return text
+		}
+		return escapeText(text, escapeTableCell, false)
+	}
+	header := make([]string, len(set.Header))
+	for i, text := range set.Header {
+		header[i] = cell(text)
+	}
+	records := set.Rows
+	if set.Footer != nil {
+		// Clipped so that the footer isn't written into the caller's rows.
+		records = append(slices.Clip(set.Rows), set.Footer)
+	}
+	rows := make([][]string, 0, len(records))
+	for _, row := range records {
+		rendered := make([]string, len(row))
+		for i, text := range row {
+			rendered[i] = cell(text)
+		}
+		rows = append(rows, rendered)
+	}
+	widths := computeColumnWidths(header, rows)
+	for i := range widths {
+		widths[i] = max(widths[i], 3)
+	}
+	return widths
+}
+
+// lineWidth returns the width of a table line holding the given columns.
+func lineWidth(widths []int, columns []int) int {
+	width := 1
+	for _, column := range columns {
+		// "| " before the cell and " " after it.
+		width += widths[column] + 3
+	}
+	return width
+}
+
+// tableWidth returns the width of the lines of set as Table writes it.
+func tableWidth(set TableSet, raw bool) int {
+	columns := make([]int, len(set.Header))
+	for i := range columns {
+		columns[i] = i
+	}
+	return lineWidth(tableWidths(set, raw), columns)
+}
+
+// columnGroups splits the columns of set into groups whose lines fit
+// maxWidth, each starting with the first keyColumns columns. A group holds
+// at least one other column even when it doesn't fit.
+func columnGroups(set TableSet, maxWidth, keyColumns int, raw bool) [][]int {
+	keyColumns = min(max(keyColumns, 0), len(set.Header))
+	keys := make([]int, keyColumns)
+	for i := range keys {
+		keys[i] = i
+	}
+	widths := tableWidths(set, raw)
+
+	var groups [][]int
+	group := append([]int(nil), keys...)
+	for column := keyColumns; column < len(set.Header); column++ {
+		if len(group) > keyColumns && lineWidth(widths, append(group, column)) > maxWidth {
+			groups = append(groups, group)
+			group = append([]int(nil), keys...)
+		}
+		group = append(group, column)
+	}
+	if len(group) > keyColumns || len(groups) == 0 {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// selectColumns returns the table holding the given columns in order.
+// Caption, column groups and spans are dropped.
+func (t TableSet) selectColumns(columns []int) TableSet {
+	pick := func(row []string) []string {
+		if row == nil {
+			return nil
+		}
+		picked := make([]string, len(columns))
+		for i, column := range columns {
+			if column < len(row) {
+				picked[i] = row[column]
+			}
+		}
+		return picked
+	}
+	selected := TableSet{Header: pick(t.Header), Rows: make([][]string, len(t.Rows)), Footer: pick(t.Footer)}
+	for i, row := range t.Rows {
+		selected.Rows[i] = pick(row)
+	}
+	if len(t.Alignment) > 0 {
+		selected.Alignment = make([]TableAlignment, len(columns))
+		for i, column := range columns {
+			if column < len(t.Alignment) {
+				selected.Alignment[i] = t.Alignment[column]
+			}
+		}
+	}
+	return selected
+}
+
+// SplitColumns splits a table whose lines are wider than maxWidth into
+// tables that fit it, each repeating the first keyColumns columns, such as
+// a name identifying the row. Widths are measured as Table writes the cells.
+// A table that fits is returned whole.
+func (t TableSet) SplitColumns(maxWidth, keyColumns int) []TableSet {
+	groups := columnGroups(t, maxWidth, keyColumns, false)
+	if len(groups) == 1 && len(groups[0]) == len(t.Header) {
+		return []TableSet{t}
+	}
+	sets := make([]TableSet, len(groups))
+	for i, group := range groups {
+		sets[i] = t.selectColumns(group)
+	}
+	return sets
+}
+
+// transposedContent returns the cells of set transposed as Transpose does,
+// styled by the values of set: the body cell at row and column is field
+// row+1 of record column-1, and the last record is the footer, if any.
+// Footer cells are bold as they are in a footer row. The first column of
+// set becomes the header, which isn't styled.
+func transposedContent(set TableSet, values [][]string, styles [][]CellStyle, raw bool) cellContent {
+	return func(row, column int, text string) []ast.Node {
+		record, field := column-1, row+1
+		switch {
+		case record >= 0 && record < len(values) && field < len(values[record]) && field < len(styles):
+			return styleCell(values[record][field], text, raw, styles[field])
+		case set.Footer != nil && record == len(set.Rows) && text != "":
+			strong := ast.NewEmphasis(2)
+			strong.AppendChild(strong, cellNode(text, raw))
+			return []ast.Node{strong}
+		}
+		return []ast.Node{cellNode(text, raw)}
+	}
+}
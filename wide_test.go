@@ -0,0 +1,144 @@
+package markdown
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func benchmarkMatrix() TableSet {
+	return TableSet{
+		Header:    []string{"Benchmark", "linux/amd64", "linux/arm64", "darwin/arm64", "windows/amd64"},
+		Alignment: []TableAlignment{AlignLeft, AlignRight, AlignRight, AlignRight, AlignRight},
+		Rows: [][]string{
+			{"Parse", "120", "135", "98", "150"},
+			{"Render", "80", "91", "70", "102"},
+		},
+	}
+}
+
+func TestTableSetSplitColumns(t *testing.T) {
+	t.Parallel()
+
+	set := benchmarkMatrix()
+	if parts := set.SplitColumns(100, 1); len(parts) != 1 || !reflect.DeepEqual(parts[0], set) {
+		t.Fatalf("expected the table whole, got %+v", parts)
+	}
+
+	parts := set.SplitColumns(45, 1)
+	var headers [][]string
+	for _, part := range parts {
+		headers = append(headers, part.Header)
+	}
+	want := [][]string{
+		{"Benchmark", "linux/amd64", "linux/arm64"},
+		{"Benchmark", "darwin/arm64", "windows/amd64"},
+	}
+	if !reflect.DeepEqual(headers, want) {
+		t.Fatalf("unexpected headers\nwant: %q\ngot:  %q", want, headers)
+	}
+	if got := parts[1].Rows[1]; !reflect.DeepEqual(got, []string{"Render", "70", "102"}) {
+		t.Fatalf("unexpected row: %q", got)
+	}
+	if got := parts[1].Alignment; !reflect.DeepEqual(got, []TableAlignment{AlignLeft, AlignRight, AlignRight}) {
+		t.Fatalf("unexpected alignment: %v", got)
+	}
+
+	// A column wider than the limit gets a table of its own.
+	if parts := set.SplitColumns(10, 1); len(parts) != 4 {
+		t.Fatalf("expected one table per column, got %d", len(parts))
+	}
+}
+
+func TestMarkdownCustomTableWide(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := NewMarkdown(&buf, WithLineEnding("\n")).
+		CustomTable(benchmarkMatrix(), TableOptions{
+			MaxTableWidth: 45,
+			KeyColumns:    1,
+			Styles:        map[string][]CellStyle{"windows/amd64": {{When: ValueAbove(100), Bold: true}}},
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := strings.Join([]string{
+		"| Benchmark | linux/amd64 | linux/arm64 |",
+		"| :-------- | ----------: | ----------: |",
+		"| Parse     |         120 |         135 |",
+		"| Render    |          80 |          91 |",
+		"",
+		"| Benchmark | darwin/arm64 | windows/amd64 |",
+		"| :-------- | -----------: | ------------: |",
+		"| Parse     |           98 |       **150** |",
+		"| Render    |           70 |       **102** |",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected tables\nwant: %q\ngot:  %q", want, got)
+	}
+
+	buf.Reset()
+	err = NewMarkdown(&buf, WithLineEnding("\n")).
+		CustomTable(benchmarkMatrix(), TableOptions{MaxTableWidth: 45, TransposeWide: true}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = strings.Join([]string{
+		"| Benchmark     | Parse | Render |",
+		"| ------------- | ----- | ------ |",
+		"| linux/amd64   | 120   | 80     |",
+		"| linux/arm64   | 135   | 91     |",
+		"| darwin/arm64  | 98    | 70     |",
+		"| windows/amd64 | 150   | 102    |",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected table\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+func TestMarkdownCustomTableTransposeWide(t *testing.T) {
+	t.Parallel()
+
+	set := benchmarkMatrix()
+	set.Footer = []string{"Total", "200", "226", "168", "252"}
+	options := TableOptions{
+		MaxTableWidth: 45,
+		TransposeWide: true,
+		Styles:        map[string][]CellStyle{"windows/amd64": {{When: ValueAbove(100), Bold: true}}},
+	}
+	got := NewMarkdown(nil, WithLineEnding("\n")).CustomTable(set, options).String()
+	want := strings.Join([]string{
+		"| Benchmark     | Parse   | Render  | Total   |",
+		"| ------------- | ------- | ------- | ------- |",
+		"| linux/amd64   | 120     | 80      | **200** |",
+		"| linux/arm64   | 135     | 91      | **226** |",
+		"| darwin/arm64  | 98      | 70      | **168** |",
+		"| windows/amd64 | **150** | **102** | **252** |",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected table\nwant: %q\ngot:  %q", want, got)
+	}
+
+	// A transposed table that is still too wide is split instead.
+	options.MaxTableWidth = 30
+	got = NewMarkdown(nil, WithLineEnding("\n")).CustomTable(set, options).String()
+	if !strings.HasPrefix(got, "| Benchmark | linux/amd64 |") {
+		t.Fatalf("expected split tables, got %q", got)
+	}
+
+	// Measuring the footer doesn't write into spare capacity of the rows.
+	rows := make([][]string, 1, 2)
+	rows[0] = []string{"a"}
+	spare := rows[:2]
+	tableWidths(TableSet{Header: []string{"A"}, Rows: rows, Footer: []string{"total"}}, false)
+	if spare[1] != nil {
+		t.Fatalf("footer was written into the rows: %q", spare)
+	}
+}
@@ -0,0 +1,61 @@
+package markdown
+
+import (
+	"io"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{"ascii", "abc", 3},
+		{"cjk", "日本語", 6},
+		{"hangul", "한국", 4},
+		{"fullwidth", "ＡＢ", 4},
+		{"halfwidth katakana", "ｱｲ", 2},
+		{"combining mark", "e\u0301", 1},
+		{"decomposed hangul", "\u1100\u1161", 2},
+		{"emoji", "👍", 2},
+		{"skin tone", "\U0001f44d\U0001f3fd", 2},
+		{"zwj sequence", "\U0001f468\u200d\U0001f469\u200d\U0001f467", 2},
+		{"flag", "🇯🇵", 2},
+		{"variation selector", "\u2764\ufe0f", 2},
+		{"keycap", "1\ufe0f\u20e3", 2},
+		{"zero width space", "a\u200bb", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := displayWidth(tt.value); got != tt.want {
+				t.Fatalf("unexpected width for %q: want %d, got %d", tt.value, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTablePaddingDisplayWidth(t *testing.T) {
+	t.Parallel()
+
+	lf := lineFeed()
+	md := NewMarkdown(io.Discard)
+	md.Table(TableSet{
+		Header:    []string{"名前", "Status", "N"},
+		Rows:      [][]string{{"東京", "✅ ok", "1"}, {"Kyoto", "\U0001f44d\U0001f3fd", "22"}},
+		Alignment: []TableAlignment{AlignLeft, AlignCenter, AlignRight},
+	})
+
+	want := "| 名前  | Status |   N |" + lf +
+		"| :---- | :----: | --: |" + lf +
+		"| 東京  | ✅ ok  |   1 |" + lf +
+		"| Kyoto |   \U0001f44d\U0001f3fd   |  22 |" + lf
+	if got := md.String(); got != want {
+		t.Fatalf("unexpected table output\nwant: %q\ngot:  %q", want, got)
+	}
+}
@@ -0,0 +1,114 @@
+package markdown
+
+import (
+	"strings"
+)
+
+// defaultMaxColumnWidth is the column width AutoWrapText wraps cells to when
+// TableOptions.MaxColumnWidth isn't set.
+const defaultMaxColumnWidth = 40
+
+// ellipsis marks the end of a truncated cell.
+const ellipsis = "…"
+
+// fitRows returns a copy of rows with every cell wrapped or truncated to
+// width. Wrapped lines are joined with lineBreak. Unless raw, cells are
+// measured as they are written, with Markdown escapes.
+func fitRows(rows [][]string, width int, truncate, raw bool, lineBreak string) [][]string {
+	measure := displayWidth
+	if !raw {
+		measure = escapedCellWidth
+	}
+	fitted := make([][]string, len(rows))
+	for i, row := range rows {
+		fitted[i] = make([]string, len(row))
+		for j, cell := range row {
+			if truncate {
+				fitted[i][j] = truncateText(cell, width, measure)
+			} else {
+				fitted[i][j] = strings.Join(wrapText(cell, width, measure), lineBreak)
+			}
+		}
+	}
+	return fitted
+}
+
+// escapedCellWidth returns the display width of a line of text escaped for
+// a table cell.
+func escapedCellWidth(text string) int {
+	return displayWidth(escapeText(text, escapeTableCell, false))
+}
+
+// wrapText breaks text into lines no wider than width as measured by
+// measure, breaking between words where possible. Words wider than width are
+// split. Existing line breaks are kept.
+func wrapText(text string, width int, measure func(string) int) []string {
+	width = max(width, 1)
+	var lines []string
+	for _, paragraph := range splitLines(text) {
+		var line strings.Builder
+		lineWidth := 0
+		flush := func() {
+			lines = append(lines, line.String())
+			line.Reset()
+			lineWidth = 0
+		}
+		for _, word := range strings.Fields(paragraph) {
+			wordWidth := measure(word)
+			if lineWidth > 0 && lineWidth+1+wordWidth > width {
+				flush()
+			}
+			for wordWidth > width {
+				head, tail := splitAtWidth(word, width, measure)
+				line.WriteString(head)
+				flush()
+				word, wordWidth = tail, measure(tail)
+			}
+			if lineWidth > 0 {
+				line.WriteByte(' ')
+				lineWidth++
+			}
+			line.WriteString(word)
+			lineWidth += wordWidth
+		}
+		flush()
+	}
+	return lines
+}
+
+// truncateText shortens text to at most width as measured by measure,
+// cutting at the last word boundary that fits and appending an ellipsis.
+// Line breaks become spaces. Widths below one are treated as one.
+func truncateText(text string, width int, measure func(string) int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	width = max(width, 1)
+	if measure(text) <= width {
+		return text
+	}
+	budget := width - displayWidth(ellipsis)
+	head, tail := splitAtWidth(text, budget, measure)
+	if measure(head) > budget {
+		// Not even the first grapheme cluster fits next to the ellipsis.
+		return ellipsis
+	}
+	if !strings.HasPrefix(tail, " ") {
+		// The cut falls inside a word; drop the partial word if another fits.
+		if cut := strings.LastIndexByte(head, ' '); cut > 0 {
+			head = head[:cut]
+		}
+	}
+	return strings.TrimRight(head, " ") + ellipsis
+}
+
+// splitAtWidth splits text after the longest prefix no wider than width as
+// measured by measure, keeping at least one grapheme cluster in the prefix.
+func splitAtWidth(text string, width int, measure func(string) int) (string, string) {
+	for i := 0; i < len(text); {
+		n, _ := nextCluster(text[i:])
+		if i > 0 && measure(text[:i+n]) > width {
+			return text[:i], text[i:]
+		}
+		i += n
+	}
+	return text, ""
+}
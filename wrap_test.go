@@ -0,0 +1,66 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{"fits", "short text", 10, []string{"short text"}},
+		{"word boundaries", "the quick brown fox", 9, []string{"the quick", "brown fox"}},
+		{"long word", "abcdefghij xy", 4, []string{"abcd", "efgh", "ij", "xy"}},
+		{"existing breaks", "one\ntwo three", 5, []string{"one", "two", "three"}},
+		{"empty", "", 5, []string{""}},
+		{"escaped width", "a|b|c d", 4, []string{"a|b", "|c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := wrapText(tt.text, tt.width, escapedCellWidth); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("unexpected lines\nwant: %q\ngot:  %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{"fits", "short text", 10, "short text"},
+		{"word boundary", "the quick brown fox", 12, "the quick…"},
+		{"escaped width", "a*b*c*d", 6, "a*b…"},
+		{"width one", "abc", 1, "…"},
+		{"width zero", "abc", 0, "…"},
+		{"wide cluster", "日本語", 2, "…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := truncateText(tt.text, tt.width, escapedCellWidth)
+			if got != tt.want {
+				t.Fatalf("unexpected text\nwant: %q\ngot:  %q", tt.want, got)
+			}
+			if width := escapedCellWidth(got); width > max(tt.width, 1) {
+				t.Fatalf("truncated text is %d wide, over %d", width, tt.width)
+			}
+		})
+	}
+}